@@ -6,30 +6,31 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/ethereum/go-ethereum/common"
-
 	"github.com/xTransact/errx/v3"
 
-	"github.com/xTransact/openseaapi/openseaapiutils"
+	"github.com/xTransact/openseaapi/chain"
 	"github.com/xTransact/openseaapi/openseamodels"
 )
 
 // GetAccount gets an OpenSea Account Profile including details such as bio, social media usernames, and profile image.
+// The address is an EVM or a Solana address: accounts are not bound to a chain.
 // DOC: https://docs.opensea.io/reference/get_account
-func (c *client) GetAccount(ctx context.Context, address common.Address,
+func (c *client) GetAccount(ctx context.Context, address chain.Address,
 	opts ...RequestOptionFn) (resp *openseamodels.Account, err error) {
 
-	if !common.IsHexAddress(address.String()) {
-		return nil, errx.New("invalid address")
+	// 账户不区分链，EVM 与 Solana 地址均可
+	if !address.IsValid() {
+		return nil, errx.Errorf("invalid address: %q", address.String())
 	}
 
 	o := new(requestOptions)
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointGetAccount, address
 
 	// GET /api/v2/accounts/{address}
-	url := fmt.Sprintf("%s/api/v2/accounts/%s", openseaapiutils.GetBaseURL(o.testnets), address.String())
+	url := fmt.Sprintf("%s/api/v2/accounts/%s", c.baseURL(o), address.String())
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -37,7 +38,7 @@ func (c *client) GetAccount(ctx context.Context, address common.Address,
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, o.testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
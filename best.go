@@ -0,0 +1,378 @@
+package openseaapi
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/xTransact/errx/v3"
+
+	"github.com/xTransact/openseaapi/openseaenums"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+// bestOrderPageSize is the page size used to scan the full order pages, the maximum allowed.
+const bestOrderPageSize = 100
+
+// isBestOrderUnsupported reports whether a best order endpoint failed because OpenSea does not serve it,
+// e.g. on a chain where it is unsupported, in which case the answer is computed from the full order pages.
+// Any other failure, a missing order included, is returned as is.
+func isBestOrderUnsupported(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotImplemented:
+		return true
+	case http.StatusBadRequest, http.StatusNotFound:
+		detail := strings.ToLower(strings.Join(apiErr.Errors, "; "))
+		if len(apiErr.Errors) == 0 {
+			detail = strings.ToLower(string(apiErr.Body))
+		}
+		return strings.Contains(detail, "not supported") || strings.Contains(detail, "unsupported")
+	default:
+		return false
+	}
+}
+
+// pricedOrder is an order found by an order page scan with the NFT contract and the unit price it is compared on.
+type pricedOrder[T any] struct {
+	order    T
+	contract common.Address
+	currency common.Address
+	price    *big.Int
+}
+
+// bestListingFromPages computes GetBestListing from the pages of GetAllListingsByCollection.
+func bestListingFromPages(ctx context.Context, s Servicer, payload *openseamodels.GetBestListingPayload,
+	opts ...RequestOptionFn) (*openseamodels.CollectionListing, error) {
+
+	it := IterAllListingsByCollection(ctx, s, &openseamodels.GetAllListingsByCollectionPayload{
+		CollectionSlug: payload.CollectionSlug,
+		Limit:          bestOrderPageSize,
+	}, opts...)
+
+	var listings []pricedOrder[*openseamodels.CollectionListing]
+	for it.Next() {
+		l := it.Item()
+		contract, id, ok := listedNft(l)
+		if !ok || id != payload.Identifier || !matchContract(payload.Contract, contract) {
+			continue
+		}
+		if currency, price, ok := listingPrice(l); ok {
+			listings = append(listings, pricedOrder[*openseamodels.CollectionListing]{l, contract, currency, price})
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	if err := singleContract(listings, payload.Identifier); err != nil {
+		return nil, err
+	}
+
+	listings, err := inPaymentToken(listings, payload.PaymentToken)
+	if err != nil {
+		return nil, err
+	}
+	if len(listings) == 0 {
+		return nil, errx.Wrapf(ErrNotFound, "no listing of %s #%s", payload.CollectionSlug, payload.Identifier)
+	}
+	best := listings[0]
+	for _, l := range listings[1:] {
+		if l.price.Cmp(best.price) < 0 {
+			best = l
+		}
+	}
+	return best.order, nil
+}
+
+// bestListingsFromPages computes GetBestListings from the pages of GetAllListingsByCollection,
+// keeping the cheapest listing of every NFT. The listings are returned in a single page without next cursor,
+// cut to payload.Limit when set: paging would scan the collection again for every page.
+func bestListingsFromPages(ctx context.Context, s Servicer, payload *openseamodels.GetBestListingsPayload,
+	opts ...RequestOptionFn) (*openseamodels.ListingsByCollectionResponse, error) {
+
+	if payload.Next != "" {
+		return nil, errx.Errorf("an order page scan returns a single page, unexpected next cursor: %s", payload.Next)
+	}
+
+	it := IterAllListingsByCollection(ctx, s, &openseamodels.GetAllListingsByCollectionPayload{
+		CollectionSlug: payload.CollectionSlug,
+		Limit:          bestOrderPageSize,
+	}, opts...)
+
+	var listings []pricedOrder[*openseamodels.CollectionListing]
+	for it.Next() {
+		l := it.Item()
+		contract, _, ok := listedNft(l)
+		if !ok {
+			continue
+		}
+		if currency, price, ok := listingPrice(l); ok {
+			listings = append(listings, pricedOrder[*openseamodels.CollectionListing]{l, contract, currency, price})
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	listings, err := inPaymentToken(listings, payload.PaymentToken)
+	if err != nil {
+		return nil, err
+	}
+
+	cheapest := make(map[string]pricedOrder[*openseamodels.CollectionListing])
+	for _, l := range listings {
+		_, id, _ := listedNft(l.order)
+		key := l.contract.Hex() + "/" + id
+		if cur, exists := cheapest[key]; !exists || l.price.Cmp(cur.price) < 0 {
+			cheapest[key] = l
+		}
+	}
+
+	sorted := make([]pricedOrder[*openseamodels.CollectionListing], 0, len(cheapest))
+	for _, l := range cheapest {
+		sorted = append(sorted, l)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if c := sorted[i].price.Cmp(sorted[j].price); c != 0 {
+			return c < 0
+		}
+		return sorted[i].order.OrderHash < sorted[j].order.OrderHash
+	})
+
+	if payload.Limit > 0 && len(sorted) > payload.Limit {
+		sorted = sorted[:payload.Limit]
+	}
+	resp := new(openseamodels.ListingsByCollectionResponse)
+	for _, l := range sorted {
+		resp.Listings = append(resp.Listings, l.order)
+	}
+	return resp, nil
+}
+
+// bestOfferFromPages computes GetBestOffer from the pages of GetAllCollectionOffers.
+func bestOfferFromPages(ctx context.Context, s Servicer, payload *openseamodels.GetBestOfferPayload,
+	opts ...RequestOptionFn) (*openseamodels.OfferResponse, error) {
+
+	it := IterAllCollectionOffers(ctx, s, &openseamodels.CollectionPayload{
+		BaseQueryParams: &openseamodels.BaseQueryParams{Limit: bestOrderPageSize},
+		CollectionSlug:  payload.CollectionSlug,
+	}, opts...)
+
+	var offers []pricedOrder[openseamodels.OfferResponse]
+	for it.Next() {
+		offer := it.Item()
+		contract, ok := offerAppliesTo(&offer, payload.Identifier)
+		if !ok || !matchContract(payload.Contract, contract) {
+			continue
+		}
+		if currency, price, ok := offerPrice(&offer); ok {
+			offers = append(offers, pricedOrder[openseamodels.OfferResponse]{offer, contract, currency, price})
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	if err := singleContract(offers, payload.Identifier); err != nil {
+		return nil, err
+	}
+
+	offers, err := inPaymentToken(offers, payload.PaymentToken)
+	if err != nil {
+		return nil, err
+	}
+	if len(offers) == 0 {
+		return nil, errx.Wrapf(ErrNotFound, "no offer on %s #%s", payload.CollectionSlug, payload.Identifier)
+	}
+	best := offers[0]
+	for _, o := range offers[1:] {
+		if o.price.Cmp(best.price) > 0 {
+			best = o
+		}
+	}
+	return &best.order, nil
+}
+
+// matchContract reports whether the NFT contract of an order is the expected one, any contract when empty.
+func matchContract(expected string, contract common.Address) bool {
+	return expected == "" || common.HexToAddress(expected) == contract
+}
+
+// singleContract fails when the orders on the identifier are on NFTs of several contracts,
+// which payload.Contract must then tell apart.
+func singleContract[T any](orders []pricedOrder[T], identifier string) error {
+	for _, o := range orders {
+		if o.contract != orders[0].contract {
+			return errx.Errorf("identifier %s matches the NFTs of several contracts: %s and %s, set the contract",
+				identifier, orders[0].contract.Hex(), o.contract.Hex())
+		}
+	}
+	return nil
+}
+
+// inPaymentToken keeps the orders paid in the payment token. Prices in different tokens cannot be compared,
+// so without payment token the orders must all be paid in the same one.
+func inPaymentToken[T any](orders []pricedOrder[T], paymentToken string) ([]pricedOrder[T], error) {
+	if paymentToken == "" {
+		for _, o := range orders {
+			if o.currency != orders[0].currency {
+				return nil, errx.Errorf("the orders are paid in several payment tokens: %s and %s, set the payment token",
+					orders[0].currency.Hex(), o.currency.Hex())
+			}
+		}
+		return orders, nil
+	}
+
+	token := common.HexToAddress(paymentToken)
+	kept := orders[:0:0]
+	for _, o := range orders {
+		if o.currency == token {
+			kept = append(kept, o)
+		}
+	}
+	return kept, nil
+}
+
+func isNftItem(t openseaenums.ItemType) bool {
+	return t >= openseaenums.ItemTypeERC721 && t <= openseaenums.ItemTypeERC1155WithCriteria
+}
+
+// listedNft returns the token contract and identifier of the NFT sold by a listing.
+func listedNft(l *openseamodels.CollectionListing) (contract common.Address, identifier string, ok bool) {
+	if l.ProtocolData == nil || l.ProtocolData.Parameters == nil {
+		return common.Address{}, "", false
+	}
+	for _, item := range l.ProtocolData.Parameters.Offer {
+		if item == nil || item.BaseOfferAndConsideration == nil {
+			continue
+		}
+		if item.ItemType == openseaenums.ItemTypeERC721 || item.ItemType == openseaenums.ItemTypeERC1155 {
+			return item.Token, item.IdentifierOrCriteria.String(), true
+		}
+	}
+	return common.Address{}, "", false
+}
+
+// listingPrice returns the payment token and the price paid per NFT by a listing: its current price,
+// the sum of its consideration items when OpenSea omits it, divided by the quantity of NFTs sold.
+// Listings whose consideration items are paid in several tokens are not priced.
+func listingPrice(l *openseamodels.CollectionListing) (currency common.Address, price *big.Int, ok bool) {
+	if l.ProtocolData == nil || l.ProtocolData.Parameters == nil {
+		return common.Address{}, nil, false
+	}
+	params := l.ProtocolData.Parameters
+	currency, total, ok := sumItems(params.Consideration, func(c *openseamodels.Consideration) *openseamodels.BaseOfferAndConsideration {
+		return c.BaseOfferAndConsideration
+	})
+	if !ok {
+		return common.Address{}, nil, false
+	}
+	if l.Price != nil && l.Price.Current != nil {
+		if current, ok := new(big.Int).SetString(l.Price.Current.Value, 10); ok {
+			total = current
+		}
+	}
+
+	quantity := nftQuantity(params.Offer, func(o *openseamodels.Offer) *openseamodels.BaseOfferAndConsideration {
+		return o.BaseOfferAndConsideration
+	})
+	if quantity.Sign() > 0 {
+		total.Quo(total, quantity)
+	}
+	return currency, total, true
+}
+
+// offerAppliesTo reports whether an offer can be fulfilled with the NFT of the given identifier,
+// returning the NFT contract: individual offers on the NFT and criteria offers on the whole collection.
+func offerAppliesTo(offer *openseamodels.OfferResponse, identifier string) (contract common.Address, ok bool) {
+	if offer.Criteria != nil && offer.Criteria.Trait != nil {
+		// 无法得知 NFT 的 traits，trait offer 一律跳过
+		return common.Address{}, false
+	}
+	if offer.ProtocolData == nil || offer.ProtocolData.Parameters == nil {
+		return common.Address{}, false
+	}
+	for _, item := range offer.ProtocolData.Parameters.Consideration {
+		if item == nil || item.BaseOfferAndConsideration == nil || !isNftItem(item.ItemType) {
+			continue
+		}
+		if offer.Criteria != nil || item.IdentifierOrCriteria.String() == identifier {
+			return item.Token, true
+		}
+	}
+	return common.Address{}, false
+}
+
+// offerPrice returns the payment token and the price paid per NFT by an offer:
+// the sum of its offer items divided by the quantity of NFTs asked for.
+// Offers paying in several tokens are not priced.
+func offerPrice(offer *openseamodels.OfferResponse) (currency common.Address, price *big.Int, ok bool) {
+	if offer.ProtocolData == nil || offer.ProtocolData.Parameters == nil {
+		return common.Address{}, nil, false
+	}
+	params := offer.ProtocolData.Parameters
+	currency, total, ok := sumItems(params.Offer, func(o *openseamodels.Offer) *openseamodels.BaseOfferAndConsideration {
+		return o.BaseOfferAndConsideration
+	})
+	if !ok {
+		return common.Address{}, nil, false
+	}
+
+	quantity := nftQuantity(params.Consideration, func(c *openseamodels.Consideration) *openseamodels.BaseOfferAndConsideration {
+		return c.BaseOfferAndConsideration
+	})
+	if quantity.Sign() > 0 {
+		total.Quo(total, quantity)
+	}
+	return currency, total, true
+}
+
+// nftQuantity sums the start amounts of the NFT items.
+func nftQuantity[T any](items []*T, base func(*T) *openseamodels.BaseOfferAndConsideration) *big.Int {
+	quantity := new(big.Int)
+	for _, it := range items {
+		if it == nil {
+			continue
+		}
+		item := base(it)
+		if item == nil || !isNftItem(item.ItemType) {
+			continue
+		}
+		if amount, ok := new(big.Int).SetString(item.StartAmount.String(), 10); ok {
+			quantity.Add(quantity, amount)
+		}
+	}
+	return quantity
+}
+
+// sumItems sums the start amounts of the fungible items, which must all be of the same token.
+func sumItems[T any](items []*T, base func(*T) *openseamodels.BaseOfferAndConsideration) (
+	token common.Address, total *big.Int, ok bool) {
+
+	total = new(big.Int)
+	for _, it := range items {
+		if it == nil {
+			continue
+		}
+		item := base(it)
+		if item == nil || isNftItem(item.ItemType) {
+			continue
+		}
+		amount, valid := new(big.Int).SetString(item.StartAmount.String(), 10)
+		if !valid {
+			continue
+		}
+		if ok && item.Token != token {
+			return common.Address{}, nil, false
+		}
+		token, ok = item.Token, true
+		total.Add(total, amount)
+	}
+	return token, total, ok
+}
@@ -0,0 +1,299 @@
+package openseaapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+const (
+	testNftContract   = "0x0000000000000000000000000000000000000001"
+	testOtherContract = "0x0000000000000000000000000000000000000003"
+	testWETH          = "0x0000000000000000000000000000000000000002"
+	testUSDC          = "0x0000000000000000000000000000000000000004"
+)
+
+// testListingJSON renders a listing of the NFT identifier of the contract priced at price wei,
+// without the price field so that the scan has to sum its consideration items.
+func testListingJSON(hash, contract, identifier string, price int) string {
+	return testLotJSON(hash, contract, identifier, price, 1)
+}
+
+// testLotJSON renders a listing of quantity ERC1155 tokens priced at price wei in total, an ERC721 when quantity is 1.
+func testLotJSON(hash, contract, identifier string, price, quantity int) string {
+	itemType := 2
+	if quantity > 1 {
+		itemType = 3
+	}
+	return fmt.Sprintf(`{"order_hash":%q,"protocol_data":{"parameters":{
+		"offer":[{"itemType":%d,"token":%q,"identifierOrCriteria":%q,"startAmount":"%d","endAmount":"%d"}],
+		"consideration":[
+			{"itemType":0,"token":"0x0000000000000000000000000000000000000000","identifierOrCriteria":"0","startAmount":"%d","endAmount":"%d"},
+			{"itemType":0,"token":"0x0000000000000000000000000000000000000000","identifierOrCriteria":"0","startAmount":"1","endAmount":"1"}]}}}`,
+		hash, itemType, contract, identifier, quantity, quantity, price-1, price-1)
+}
+
+// testOfferJSON renders an offer paying value of the currency for quantity NFTs of testNftContract,
+// the NFT identifier or any NFT matching the criteria when not empty.
+func testOfferJSON(hash, identifier string, value, quantity int, currency, criteria string) string {
+	itemType := 2
+	if criteria == "" {
+		criteria = "null"
+	} else {
+		itemType = 4
+	}
+	return fmt.Sprintf(`{"order_hash":%q,"criteria":%s,"protocol_data":{"parameters":{
+		"offer":[{"itemType":1,"token":%q,"identifierOrCriteria":"0","startAmount":"%d","endAmount":"%d"}],
+		"consideration":[{"itemType":%d,"token":%q,"identifierOrCriteria":%q,"startAmount":"%d","endAmount":"%d"}]}}}`,
+		hash, criteria, currency, value, value, itemType, testNftContract, identifier, quantity, quantity)
+}
+
+func TestGetBestListing(t *testing.T) {
+	var paths []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path+"?"+r.URL.RawQuery)
+		_, _ = w.Write([]byte(`{"order_hash":"0xbest","chain":"ethereum","price":{"current":{"currency":"ETH","decimals":18,"value":"1000"}}}`))
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL))
+	resp, err := cli.GetBestListing(context.Background(), &openseamodels.GetBestListingPayload{
+		CollectionSlug:         "azuki",
+		Identifier:             "7",
+		IncludePrivateListings: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0xbest", resp.OrderHash)
+	assert.Equal(t, "ethereum", resp.Chain)
+	require.NotNil(t, resp.Price)
+	assert.Equal(t, "1000", resp.Price.Current.Value)
+	assert.Equal(t, []string{"/api/v2/listings/collection/azuki/nfts/7/best?include_private_listings=true"}, paths)
+
+	_, err = cli.GetBestListing(context.Background(), &openseamodels.GetBestListingPayload{CollectionSlug: "azuki"})
+	assert.Error(t, err)
+}
+
+// newScanServer serves the given pages on the full order endpoints, the best order endpoints must not be called.
+func newScanServer(t *testing.T, listings, offers []string) *httptest.Server {
+	page := func(w http.ResponseWriter, r *http.Request, field string, items []string) {
+		// 每页一个订单，next 为下一个订单的下标
+		i := 0
+		_, _ = fmt.Sscan(r.URL.Query().Get("next"), &i)
+		next := ""
+		if i+1 < len(items) {
+			next = fmt.Sprint(i + 1)
+		}
+		_, _ = fmt.Fprintf(w, `{%q:[%s],"next":%q}`, field, items[i], next)
+	}
+	return newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/v2/listings/collection/azuki/all"):
+			page(w, r, "listings", listings)
+		case strings.HasPrefix(r.URL.Path, "/api/v2/offers/collection/azuki/all"):
+			page(w, r, "offers", offers)
+		default:
+			t.Errorf("unexpected call to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestGetBestListingScan(t *testing.T) {
+	srv := newScanServer(t, []string{
+		testListingJSON("0xa", testNftContract, "7", 300),
+		testListingJSON("0xb", testNftContract, "8", 100),
+		testListingJSON("0xc", testNftContract, "7", 200),
+		testListingJSON("0xd", testNftContract, "7", 250),
+		testListingJSON("0xe", testNftContract, "9", 150),
+	}, nil)
+
+	ctx := context.Background()
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL))
+
+	resp, err := cli.GetBestListing(ctx, &openseamodels.GetBestListingPayload{CollectionSlug: "azuki", Identifier: "7"},
+		ScanOrderPages())
+	require.NoError(t, err)
+	assert.Equal(t, "0xc", resp.OrderHash)
+
+	_, err = cli.GetBestListing(ctx, &openseamodels.GetBestListingPayload{CollectionSlug: "azuki", Identifier: "10"},
+		ScanOrderPages())
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// 扫描结果只有一页，按 limit 截断
+	hashes := func(resp *openseamodels.ListingsByCollectionResponse) []string {
+		var hashes []string
+		for _, l := range resp.Listings {
+			hashes = append(hashes, l.OrderHash)
+		}
+		return hashes
+	}
+	best, err := cli.GetBestListings(ctx, &openseamodels.GetBestListingsPayload{CollectionSlug: "azuki"}, ScanOrderPages())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0xb", "0xe", "0xc"}, hashes(best))
+	assert.Empty(t, best.Next)
+
+	best, err = cli.GetBestListings(ctx, &openseamodels.GetBestListingsPayload{CollectionSlug: "azuki", Limit: 2},
+		ScanOrderPages())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0xb", "0xe"}, hashes(best))
+	assert.Empty(t, best.Next)
+
+	_, err = cli.GetBestListings(ctx, &openseamodels.GetBestListingsPayload{CollectionSlug: "azuki", Next: "cursor"},
+		ScanOrderPages())
+	assert.Error(t, err)
+}
+
+func TestGetBestListingScanPerUnit(t *testing.T) {
+	srv := newScanServer(t, []string{
+		testListingJSON("0xa", testNftContract, "7", 300),
+		// 5 个共 1000，每个 200
+		testLotJSON("0xb", testNftContract, "7", 1000, 5),
+		testLotJSON("0xc", testNftContract, "8", 600, 2),
+	}, nil)
+
+	ctx := context.Background()
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL))
+
+	resp, err := cli.GetBestListing(ctx, &openseamodels.GetBestListingPayload{CollectionSlug: "azuki", Identifier: "7"},
+		ScanOrderPages())
+	require.NoError(t, err)
+	assert.Equal(t, "0xb", resp.OrderHash)
+
+	best, err := cli.GetBestListings(ctx, &openseamodels.GetBestListingsPayload{CollectionSlug: "azuki"}, ScanOrderPages())
+	require.NoError(t, err)
+	require.Len(t, best.Listings, 2)
+	assert.Equal(t, "0xb", best.Listings[0].OrderHash)
+	assert.Equal(t, "0xc", best.Listings[1].OrderHash)
+}
+
+func TestGetBestListingScanContracts(t *testing.T) {
+	srv := newScanServer(t, []string{
+		testListingJSON("0xa", testNftContract, "7", 300),
+		testListingJSON("0xb", testOtherContract, "7", 100),
+	}, nil)
+
+	ctx := context.Background()
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL))
+
+	// 同一 identifier 对应多个合约时必须指定合约
+	_, err := cli.GetBestListing(ctx, &openseamodels.GetBestListingPayload{CollectionSlug: "azuki", Identifier: "7"},
+		ScanOrderPages())
+	assert.ErrorContains(t, err, "several contracts")
+
+	resp, err := cli.GetBestListing(ctx, &openseamodels.GetBestListingPayload{
+		CollectionSlug: "azuki",
+		Identifier:     "7",
+		Contract:       testNftContract,
+	}, ScanOrderPages())
+	require.NoError(t, err)
+	assert.Equal(t, "0xa", resp.OrderHash)
+}
+
+func TestGetBestOfferScan(t *testing.T) {
+	srv := newScanServer(t, nil, []string{
+		testOfferJSON("0xa", "7", 100, 1, testWETH, ""),
+		testOfferJSON("0xb", "8", 900, 1, testWETH, ""),
+		testOfferJSON("0xc", "0", 300, 1, testWETH, `{"collection":{"slug":"azuki"}}`),
+		testOfferJSON("0xd", "0", 800, 1, testWETH, `{"collection":{"slug":"azuki"},"trait":{"type":"Background","value":"Red"}}`),
+		// 5 个 NFT 共 1000，每个 200
+		testOfferJSON("0xe", "0", 1000, 5, testWETH, `{"collection":{"slug":"azuki"}}`),
+		// USDC 与 WETH 的数值不可比较，需要指定支付代币
+		testOfferJSON("0xf", "7", 5000, 1, testUSDC, ""),
+	})
+
+	ctx := context.Background()
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL))
+
+	_, err := cli.GetBestOffer(ctx, &openseamodels.GetBestOfferPayload{CollectionSlug: "azuki", Identifier: "7"},
+		ScanOrderPages())
+	assert.ErrorContains(t, err, "several payment tokens")
+
+	resp, err := cli.GetBestOffer(ctx, &openseamodels.GetBestOfferPayload{
+		CollectionSlug: "azuki",
+		Identifier:     "7",
+		PaymentToken:   testWETH,
+	}, ScanOrderPages())
+	require.NoError(t, err)
+	assert.Equal(t, "0xc", resp.OrderHash)
+
+	resp, err = cli.GetBestOffer(ctx, &openseamodels.GetBestOfferPayload{
+		CollectionSlug: "azuki",
+		Identifier:     "7",
+		PaymentToken:   testUSDC,
+	}, ScanOrderPages())
+	require.NoError(t, err)
+	assert.Equal(t, "0xf", resp.OrderHash)
+
+	resp, err = cli.GetBestOffer(ctx, &openseamodels.GetBestOfferPayload{CollectionSlug: "azuki", Identifier: "8"},
+		ScanOrderPages())
+	require.NoError(t, err)
+	assert.Equal(t, "0xb", resp.OrderHash)
+
+	_, err = cli.GetBestOffer(ctx, &openseamodels.GetBestOfferPayload{
+		CollectionSlug: "azuki",
+		Identifier:     "7",
+		Contract:       testOtherContract,
+	}, ScanOrderPages())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGetBestOrderUnsupported(t *testing.T) {
+	listings := []string{
+		testListingJSON("0xa", testNftContract, "7", 300),
+		testListingJSON("0xb", testNftContract, "7", 200),
+	}
+	offers := []string{testOfferJSON("0xc", "7", 100, 1, testWETH, "")}
+	var best []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/best"):
+			best = append(best, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["Chain klaytn is not supported for this endpoint"]}`))
+		case strings.HasPrefix(r.URL.Path, "/api/v2/listings/collection/azuki/all"):
+			_, _ = fmt.Fprintf(w, `{"listings":[%s]}`, strings.Join(listings, ","))
+		case strings.HasPrefix(r.URL.Path, "/api/v2/offers/collection/azuki/all"):
+			_, _ = fmt.Fprintf(w, `{"offers":[%s]}`, strings.Join(offers, ","))
+		}
+	})
+
+	// OpenSea 不支持该 endpoint 时自动扫描订单页
+	ctx := context.Background()
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL))
+	listing, err := cli.GetBestListing(ctx, &openseamodels.GetBestListingPayload{CollectionSlug: "azuki", Identifier: "7"})
+	require.NoError(t, err)
+	assert.Equal(t, "0xb", listing.OrderHash)
+
+	page, err := cli.GetBestListings(ctx, &openseamodels.GetBestListingsPayload{CollectionSlug: "azuki"})
+	require.NoError(t, err)
+	require.Len(t, page.Listings, 1)
+	assert.Equal(t, "0xb", page.Listings[0].OrderHash)
+
+	offer, err := cli.GetBestOffer(ctx, &openseamodels.GetBestOfferPayload{CollectionSlug: "azuki", Identifier: "7"})
+	require.NoError(t, err)
+	assert.Equal(t, "0xc", offer.OrderHash)
+	assert.Len(t, best, 3)
+}
+
+func TestGetBestOrderNotFound(t *testing.T) {
+	calls := 0
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	// 未指定 ScanOrderPages 时 404 原样返回，不扫描订单页
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL))
+	_, err := cli.GetBestOffer(context.Background(), &openseamodels.GetBestOfferPayload{CollectionSlug: "azuki", Identifier: "7"})
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = cli.GetBestListing(context.Background(), &openseamodels.GetBestListingPayload{CollectionSlug: "azuki", Identifier: "7"})
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 2, calls)
+}
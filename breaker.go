@@ -0,0 +1,366 @@
+package openseaapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/xTransact/errx/v3"
+)
+
+// ErrCircuitOpen is matched through errors.Is by the *CircuitOpenError returned while a circuit is open.
+var ErrCircuitOpen = errors.New("opensea: circuit open")
+
+// CircuitOpenError is returned without calling OpenSea while the circuit of the endpoint group is open.
+type CircuitOpenError struct {
+	Group EndpointGroup
+	// RetryAt is when the circuit lets a probe call through.
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("opensea: circuit of the %s endpoints open until %s", e.Group, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// EndpointGroup is a group of endpoints sharing a circuit, following the sections of the OpenSea API reference.
+type EndpointGroup int
+
+const (
+	// EndpointGroupNFT are the accounts, NFTs, contracts, collections and traits endpoints.
+	EndpointGroupNFT EndpointGroup = iota
+	// EndpointGroupAnalytics are the collection stats and events endpoints.
+	EndpointGroupAnalytics
+	// EndpointGroupMarketplace are the listings, offers and orders endpoints.
+	EndpointGroupMarketplace
+)
+
+// valid reports whether g is one of the EndpointGroup constants.
+func (g EndpointGroup) valid() bool {
+	return g >= EndpointGroupNFT && g <= EndpointGroupMarketplace
+}
+
+func (g EndpointGroup) String() string {
+	switch g {
+	case EndpointGroupNFT:
+		return "nft"
+	case EndpointGroupAnalytics:
+		return "analytics"
+	case EndpointGroupMarketplace:
+		return "marketplace"
+	default:
+		return ""
+	}
+}
+
+var endpointGroups = map[string]EndpointGroup{
+	EndpointGetCollectionStats:         EndpointGroupAnalytics,
+	EndpointListEventsByAccount:        EndpointGroupAnalytics,
+	EndpointListEventsByNft:            EndpointGroupAnalytics,
+	EndpointListEventsByCollection:     EndpointGroupAnalytics,
+	EndpointBuildOffer:                 EndpointGroupMarketplace,
+	EndpointGetCollectionOffers:        EndpointGroupMarketplace,
+	EndpointCreateCriteriaOffer:        EndpointGroupMarketplace,
+	EndpointCreateIndividualOffer:      EndpointGroupMarketplace,
+	EndpointCreateListing:              EndpointGroupMarketplace,
+	EndpointFulfillListing:             EndpointGroupMarketplace,
+	EndpointFulfillOffer:               EndpointGroupMarketplace,
+	EndpointGetAllListingsByCollection: EndpointGroupMarketplace,
+	EndpointGetAllCollectionOffers:     EndpointGroupMarketplace,
+	EndpointGetIndividualOffers:        EndpointGroupMarketplace,
+	EndpointGetListings:                EndpointGroupMarketplace,
+	EndpointGetOrder:                   EndpointGroupMarketplace,
+	EndpointCancelOrder:                EndpointGroupMarketplace,
+	EndpointGetTraitOffers:             EndpointGroupMarketplace,
+	EndpointGetBestListing:             EndpointGroupMarketplace,
+	EndpointGetBestListings:            EndpointGroupMarketplace,
+	EndpointGetBestOffer:               EndpointGroupMarketplace,
+}
+
+// EndpointGroupOf returns the group of an endpoint, see the Endpoint constants.
+func EndpointGroupOf(endpoint string) EndpointGroup {
+	if g, ok := endpointGroups[endpoint]; ok {
+		return g
+	}
+	return EndpointGroupNFT
+}
+
+// BreakerState is the state of a circuit.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every call through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails every call fast with a *CircuitOpenError.
+	BreakerOpen
+	// BreakerHalfOpen lets a few probe calls through, closing the circuit on success and opening it again on failure.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return ""
+	}
+}
+
+// BreakerSettings configures the circuit of an endpoint group.
+type BreakerSettings struct {
+	// FailureThreshold is the number of consecutive failed calls opening the circuit. Default: 5
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before letting probe calls through. Default: 30s
+	OpenTimeout time.Duration
+	// HalfOpenMaxCalls is the number of concurrent probe calls allowed while half-open. Default: 1
+	HalfOpenMaxCalls int
+	// IsFailure reports whether a call counts as a failure, only the calls without error count as successes.
+	// The calls whose context was canceled or timed out are neither.
+	// Default: 5xx responses and transport errors, timeouts of the http client included.
+	IsFailure func(resp *Response, err error) bool
+	// Now is the clock of the circuit, mostly useful in tests. Default: time.Now
+	Now func() time.Time
+}
+
+func (s BreakerSettings) withDefaults() BreakerSettings {
+	if s.FailureThreshold <= 0 {
+		s.FailureThreshold = 5
+	}
+	if s.OpenTimeout <= 0 {
+		s.OpenTimeout = 30 * time.Second
+	}
+	if s.HalfOpenMaxCalls <= 0 {
+		s.HalfOpenMaxCalls = 1
+	}
+	if s.IsFailure == nil {
+		s.IsFailure = isBreakerFailure
+	}
+	if s.Now == nil {
+		s.Now = time.Now
+	}
+	return s
+}
+
+func isBreakerFailure(_ *Response, err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	// http client 的错误，包括其超时
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	// 其余的 context 错误来自限流器，不说明 OpenSea 出了故障
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	// 读取响应体的错误
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// CircuitBreaker fails the calls of an endpoint group fast while OpenSea is failing them, see WithCircuitBreaker.
+// Every endpoint group has its own circuit.
+type CircuitBreaker struct {
+	mu            sync.Mutex
+	circuits      [3]*circuit
+	onStateChange func(group EndpointGroup, from, to BreakerState)
+}
+
+type circuit struct {
+	settings BreakerSettings
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// NewCircuitBreaker creates a breaker whose circuits all use the settings, see SetSettings to configure a group.
+func NewCircuitBreaker(settings BreakerSettings) *CircuitBreaker {
+	b := new(CircuitBreaker)
+	for i := range b.circuits {
+		b.circuits[i] = &circuit{settings: settings.withDefaults()}
+	}
+	return b
+}
+
+// SetSettings configures the circuit of an endpoint group, failing on an unknown group.
+func (b *CircuitBreaker) SetSettings(group EndpointGroup, settings BreakerSettings) error {
+	if !group.valid() {
+		return errx.Errorf("unknown endpoint group: %d", group)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.circuits[group].settings = settings.withDefaults()
+	return nil
+}
+
+// OnStateChange registers a callback called on every state change of a circuit.
+// It is called synchronously, outside the lock of the breaker.
+func (b *CircuitBreaker) OnStateChange(fn func(group EndpointGroup, from, to BreakerState)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onStateChange = fn
+}
+
+// State returns the state of the circuit of an endpoint group, BreakerClosed for an unknown group.
+func (b *CircuitBreaker) State(group EndpointGroup) BreakerState {
+	if !group.valid() {
+		return BreakerClosed
+	}
+
+	b.mu.Lock()
+	c := b.circuits[group]
+	from := c.state
+	b.refresh(c)
+	to, fn := c.state, b.onStateChange
+	b.mu.Unlock()
+
+	b.notify(fn, group, from, to)
+	return to
+}
+
+// refresh moves an open circuit to half-open once its timeout elapsed.
+func (b *CircuitBreaker) refresh(c *circuit) {
+	if c.state == BreakerOpen && !c.settings.Now().Before(c.openedAt.Add(c.settings.OpenTimeout)) {
+		c.state, c.probes = BreakerHalfOpen, 0
+	}
+}
+
+func (b *CircuitBreaker) notify(fn func(EndpointGroup, BreakerState, BreakerState), group EndpointGroup, from, to BreakerState) {
+	if fn != nil && from != to {
+		fn(group, from, to)
+	}
+}
+
+// allow reports whether a call of the group may be sent, returning a *CircuitOpenError if not.
+func (b *CircuitBreaker) allow(group EndpointGroup) error {
+	if !group.valid() {
+		return nil
+	}
+
+	b.mu.Lock()
+	c := b.circuits[group]
+	from := c.state
+	b.refresh(c)
+	to, fn := c.state, b.onStateChange
+
+	var err error
+	switch c.state {
+	case BreakerOpen:
+		err = &CircuitOpenError{Group: group, RetryAt: c.openedAt.Add(c.settings.OpenTimeout)}
+	case BreakerHalfOpen:
+		if c.probes >= c.settings.HalfOpenMaxCalls {
+			err = &CircuitOpenError{Group: group, RetryAt: c.settings.Now()}
+		} else {
+			c.probes++
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(fn, group, from, to)
+	return err
+}
+
+// peek returns the state of the circuit of an endpoint group like State, without moving nor notifying it.
+func (b *CircuitBreaker) peek(group EndpointGroup) BreakerState {
+	if !group.valid() {
+		return BreakerClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuits[group]
+	if c.state == BreakerOpen && !c.settings.Now().Before(c.openedAt.Add(c.settings.OpenTimeout)) {
+		return BreakerHalfOpen
+	}
+	return c.state
+}
+
+// record accounts the outcome of a call allowed by allow.
+// Only a failure opens the circuit and only a success, a call without error, closes it:
+// the other outcomes, e.g. a 4xx response, leave the circuit as it is.
+func (b *CircuitBreaker) record(group EndpointGroup, resp *Response, err error) {
+	if !group.valid() {
+		return
+	}
+
+	b.mu.Lock()
+	c := b.circuits[group]
+	from := c.state
+	failed := c.settings.IsFailure(resp, err)
+	succeeded := !failed && err == nil
+
+	switch c.state {
+	case BreakerClosed:
+		if succeeded {
+			c.failures = 0
+		} else if failed {
+			if c.failures++; c.failures >= c.settings.FailureThreshold {
+				c.state, c.openedAt = BreakerOpen, c.settings.Now()
+			}
+		}
+	case BreakerHalfOpen:
+		c.probes = max(c.probes-1, 0)
+		if failed {
+			c.state, c.openedAt = BreakerOpen, c.settings.Now()
+		} else if succeeded {
+			c.state, c.failures = BreakerClosed, 0
+		}
+	}
+	to, fn := c.state, b.onStateChange
+	b.mu.Unlock()
+
+	b.notify(fn, group, from, to)
+}
+
+// abandon releases a call allowed by allow which its caller gave up on, without accounting it.
+func (b *CircuitBreaker) abandon(group EndpointGroup) {
+	if !group.valid() {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c := b.circuits[group]; c.state == BreakerHalfOpen {
+		c.probes = max(c.probes-1, 0)
+	}
+}
+
+// breaking fails the calls going through the doer fast while the circuit of their endpoint group is open.
+func (c *client) breaking(next Doer) Doer {
+	breaker := c.config.breaker
+
+	return DoerFunc(func(req *Request) (*Response, error) {
+		group := EndpointGroupOf(req.Endpoint)
+		if err := breaker.allow(group); err != nil {
+			return nil, err
+		}
+
+		resp, err := next.Do(req)
+		if req.Context().Err() != nil {
+			// 调用方取消或超时，不说明 OpenSea 的状态
+			breaker.abandon(group)
+		} else {
+			breaker.record(group, resp, err)
+		}
+		return resp, err
+	})
+}
@@ -0,0 +1,197 @@
+package openseaapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xTransact/errx/v3"
+)
+
+type stateChange struct {
+	group    EndpointGroup
+	from, to BreakerState
+}
+
+func TestCircuitBreakerStates(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	b := NewCircuitBreaker(BreakerSettings{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+		Now:              func() time.Time { return now },
+	})
+
+	var changes []stateChange
+	b.OnStateChange(func(group EndpointGroup, from, to BreakerState) {
+		changes = append(changes, stateChange{group, from, to})
+	})
+
+	failure := &APIError{StatusCode: http.StatusBadGateway}
+	group := EndpointGroupNFT
+
+	// a success resets the consecutive failures
+	for _, err := range []error{failure, nil, failure, failure} {
+		require.NoError(t, b.allow(group))
+		b.record(group, nil, err)
+	}
+	assert.Equal(t, BreakerOpen, b.State(group))
+	assert.Equal(t, BreakerClosed, b.State(EndpointGroupMarketplace))
+
+	err := b.allow(group)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	var openErr *CircuitOpenError
+	require.True(t, errors.As(err, &openErr))
+	assert.Equal(t, now.Add(time.Minute), openErr.RetryAt)
+
+	// a single probe is let through once the timeout elapsed, its failure opens the circuit again
+	now = now.Add(time.Minute)
+	require.NoError(t, b.allow(group))
+	require.ErrorIs(t, b.allow(group), ErrCircuitOpen)
+	b.record(group, nil, &url.Error{Op: "Get", URL: "https://api.opensea.io", Err: syscall.ECONNREFUSED})
+	assert.Equal(t, BreakerOpen, b.State(group))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, b.allow(group))
+	b.record(group, nil, nil)
+	assert.Equal(t, BreakerClosed, b.State(group))
+
+	assert.Equal(t, []stateChange{
+		{group, BreakerClosed, BreakerOpen},
+		{group, BreakerOpen, BreakerHalfOpen},
+		{group, BreakerHalfOpen, BreakerOpen},
+		{group, BreakerOpen, BreakerHalfOpen},
+		{group, BreakerHalfOpen, BreakerClosed},
+	}, changes)
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	b := NewCircuitBreaker(BreakerSettings{FailureThreshold: 1})
+	b.record(EndpointGroupNFT, nil, &APIError{StatusCode: http.StatusNotFound})
+	b.record(EndpointGroupNFT, nil, &APIError{StatusCode: http.StatusTooManyRequests})
+	b.record(EndpointGroupNFT, nil, errx.Wrap(context.DeadlineExceeded, "rate limiter: wait exceeds the context deadline"))
+	b.record(EndpointGroupNFT, nil, errors.New("rebuild request body"))
+	assert.Equal(t, BreakerClosed, b.State(EndpointGroupNFT))
+
+	b.record(EndpointGroupNFT, nil, &url.Error{Op: "Get", URL: "https://api.opensea.io", Err: syscall.ECONNREFUSED})
+	assert.Equal(t, BreakerOpen, b.State(EndpointGroupNFT))
+
+	// http client 的超时也是故障
+	assert.True(t, isBreakerFailure(nil, &url.Error{Op: "Get", URL: "https://api.opensea.io", Err: context.DeadlineExceeded}))
+
+	require.NoError(t, b.SetSettings(EndpointGroupAnalytics, BreakerSettings{
+		FailureThreshold: 1,
+		IsFailure:        func(resp *Response, err error) bool { return err != nil },
+	}))
+	b.record(EndpointGroupAnalytics, nil, &APIError{StatusCode: http.StatusNotFound})
+	assert.Equal(t, BreakerOpen, b.State(EndpointGroupAnalytics))
+}
+
+func TestCircuitBreakerHalfOpenNeutral(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	b := NewCircuitBreaker(BreakerSettings{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+		Now:              func() time.Time { return now },
+	})
+	group := EndpointGroupMarketplace
+	b.record(group, nil, &APIError{StatusCode: http.StatusBadGateway})
+
+	// peek 不改变状态，也不通知
+	var changes []stateChange
+	b.OnStateChange(func(group EndpointGroup, from, to BreakerState) {
+		changes = append(changes, stateChange{group, from, to})
+	})
+	now = now.Add(time.Minute)
+	assert.Equal(t, BreakerHalfOpen, b.peek(group))
+	assert.Equal(t, BreakerOpen, b.circuits[group].state)
+	assert.Empty(t, changes)
+
+	// 4xx 与放弃的探测不关闭熔断，但释放探测名额
+	require.NoError(t, b.allow(group))
+	b.record(group, nil, &APIError{StatusCode: http.StatusNotFound})
+	assert.Equal(t, BreakerHalfOpen, b.State(group))
+	require.NoError(t, b.allow(group))
+	b.abandon(group)
+	assert.Equal(t, BreakerHalfOpen, b.State(group))
+
+	require.NoError(t, b.allow(group))
+	b.record(group, &Response{StatusCode: http.StatusOK}, nil)
+	assert.Equal(t, BreakerClosed, b.State(group))
+}
+
+func TestCircuitBreakerCallerGaveUp(t *testing.T) {
+	release := make(chan struct{})
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	t.Cleanup(func() { close(release) })
+
+	breaker := NewCircuitBreaker(BreakerSettings{FailureThreshold: 1})
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithCircuitBreaker(breaker), WithRetryPolicy(NoRetryPolicy()))
+
+	// 调用方超时不计入
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := cli.GetCollection(ctx, "azuki")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, BreakerClosed, breaker.State(EndpointGroupNFT))
+
+	// http client 超时说明 OpenSea 无响应
+	cli = NewClient(WithBaseURL(srv.URL, ""), WithCircuitBreaker(breaker), WithRetryPolicy(NoRetryPolicy()),
+		WithTimeout(20*time.Millisecond))
+	_, err = cli.GetCollection(context.Background(), "azuki")
+	require.Error(t, err)
+	assert.Equal(t, BreakerOpen, breaker.State(EndpointGroupNFT))
+}
+
+func TestCircuitBreakerUnknownGroup(t *testing.T) {
+	b := NewCircuitBreaker(BreakerSettings{FailureThreshold: 1})
+	group := EndpointGroup(42)
+
+	assert.Error(t, b.SetSettings(group, BreakerSettings{}))
+	assert.Error(t, b.SetSettings(-1, BreakerSettings{}))
+	b.record(group, nil, &APIError{StatusCode: http.StatusBadGateway})
+	assert.NoError(t, b.allow(group))
+	assert.Equal(t, BreakerClosed, b.State(group))
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	var hits atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	breaker := NewCircuitBreaker(BreakerSettings{FailureThreshold: 2, OpenTimeout: time.Hour})
+	cli := NewClient(
+		WithBaseURL(srv.URL, ""),
+		WithCircuitBreaker(breaker),
+		WithRetryPolicy(NoRetryPolicy()),
+	)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, err := cli.GetCollection(ctx, "azuki")
+		require.ErrorIs(t, err, ErrServer)
+	}
+
+	_, err := cli.GetTraits(ctx, "azuki")
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.EqualValues(t, 2, hits.Load())
+
+	// the other groups have their own circuit
+	_, err = cli.GetCollectionStats(ctx, "azuki")
+	require.ErrorIs(t, err, ErrServer)
+	assert.EqualValues(t, 3, hits.Load())
+	assert.Equal(t, BreakerClosed, breaker.State(EndpointGroupAnalytics))
+}
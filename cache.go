@@ -0,0 +1,281 @@
+package openseaapi
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xTransact/errx/v3"
+)
+
+// Cache stores the raw bodies of successful responses, see WithCache.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the body stored under key, ok is false when it is missing or expired.
+	Get(key string) (body []byte, ok bool)
+	// Set stores body under key for ttl.
+	Set(key string, body []byte, ttl time.Duration)
+	// Delete removes key from the cache.
+	Delete(key string)
+}
+
+// DefaultCacheTTLs are the TTLs of the endpoints cached by WithCache,
+// all of them return data which changes slowly. Override them with WithCacheTTL.
+var DefaultCacheTTLs = map[string]time.Duration{
+	EndpointGetCollection:      10 * time.Minute,
+	EndpointGetContract:        time.Hour,
+	EndpointGetTraits:          10 * time.Minute,
+	EndpointGetAccount:         10 * time.Minute,
+	EndpointGetCollectionStats: time.Minute,
+}
+
+// uncacheableEndpoints are never cached whatever their TTL: orders and offers are stale as soon as they are read.
+var uncacheableEndpoints = map[string]bool{
+	EndpointBuildOffer:                 true,
+	EndpointGetCollectionOffers:        true,
+	EndpointCreateCriteriaOffer:        true,
+	EndpointCreateIndividualOffer:      true,
+	EndpointCreateListing:              true,
+	EndpointFulfillListing:             true,
+	EndpointFulfillOffer:               true,
+	EndpointGetAllListingsByCollection: true,
+	EndpointGetAllCollectionOffers:     true,
+	EndpointGetIndividualOffers:        true,
+	EndpointGetListings:                true,
+	EndpointGetOrder:                   true,
+	EndpointCancelOrder:                true,
+	EndpointGetTraitOffers:             true,
+	EndpointGetBestListing:             true,
+	EndpointGetBestListings:            true,
+	EndpointGetBestOffer:               true,
+}
+
+// CacheControl selects how a single call uses the cache of the client.
+type CacheControl int
+
+const (
+	// CacheDefault serves the call from the cache when possible and stores its response.
+	CacheDefault CacheControl = iota
+	// CacheBypass neither reads nor writes the cache.
+	CacheBypass
+	// CacheRefresh skips the cached response but stores the fresh one.
+	CacheRefresh
+)
+
+// cacheTTL returns the TTL of the endpoint, 0 when its responses must not be cached.
+func (c *client) cacheTTL(req *Request) time.Duration {
+	if req.HTTPRequest.Method != http.MethodGet || uncacheableEndpoints[req.Endpoint] {
+		return 0
+	}
+	if ttl, ok := c.config.cacheTTLs[req.Endpoint]; ok {
+		return ttl
+	}
+	return DefaultCacheTTLs[req.Endpoint]
+}
+
+// cacheKey identifies a call by its endpoint, chain, environment, path and encoded query.
+func cacheKey(req *Request) string {
+	u := req.HTTPRequest.URL
+	return strings.Join([]string{
+		req.Endpoint,
+		req.Chain.Value(),
+		strconv.FormatBool(req.Testnets),
+		u.Host + u.Path,
+		u.Query().Encode(),
+	}, "|")
+}
+
+// cacheOf returns the cache of a call and its TTL: the in-process cache of GetPaymentToken,
+// the cache of WithCache for the other endpoints, nil when the call is not cached.
+func (c *client) cacheOf(req *Request) (Cache, time.Duration) {
+	if req.Endpoint == EndpointGetPaymentToken {
+		return c.paymentTokens, c.paymentTokenTTL()
+	}
+	if c.config.cache == nil {
+		return nil, 0
+	}
+	return c.config.cache, c.cacheTTL(req)
+}
+
+// caching serves the cacheable calls going through the doer from their cache, see WithCache and GetPaymentToken.
+func (c *client) caching(next Doer) Doer {
+	return DoerFunc(func(req *Request) (*Response, error) {
+		cache, ttl := c.cacheOf(req)
+		control := req.options.cacheControl
+		if cache == nil || ttl <= 0 || control == CacheBypass || req.HTTPRequest.Method != http.MethodGet {
+			return next.Do(req)
+		}
+
+		key := cacheKey(req)
+		if control != CacheRefresh {
+			if body, ok := cache.Get(key); ok {
+				return &Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: body}, nil
+			}
+		}
+
+		resp, err := next.Do(req)
+		if err == nil && resp.StatusCode == http.StatusOK {
+			cache.Set(key, resp.Body, ttl)
+		}
+		return resp, err
+	})
+}
+
+// LRUCache is an in-memory Cache evicting the least recently used entries beyond its capacity.
+type LRUCache struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	capacity int
+	ll       *list.List
+	entries  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key       string
+	body      []byte
+	expiresAt time.Time
+}
+
+// NewLRUCache creates an in-memory cache holding at most capacity responses.
+// A capacity <= 0 leaves the cache unbounded.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		now:      time.Now,
+		capacity: capacity,
+		ll:       list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return entry.body, true
+}
+
+func (c *LRUCache) Set(key string, body []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.body, entry.expiresAt = body, expiresAt
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.ll.PushFront(&lruEntry{key: key, body: body, expiresAt: expiresAt})
+	for c.capacity > 0 && c.ll.Len() > c.capacity {
+		c.remove(c.ll.Back())
+	}
+}
+
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// Len returns the number of entries in the cache, expired ones included.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+func (c *LRUCache) remove(elem *list.Element) {
+	c.ll.Remove(elem)
+	delete(c.entries, elem.Value.(*lruEntry).key)
+}
+
+// DirCache is a Cache storing every response in its own file of a directory,
+// so that it survives restarts and can be shared by several processes.
+// I/O errors are treated as cache misses.
+type DirCache struct {
+	dir string
+	now func() time.Time
+}
+
+type dirEntry struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Body      []byte    `json:"body"`
+}
+
+// NewDirCache creates a cache storing its entries in dir, creating the directory if needed.
+func NewDirCache(dir string) (*DirCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errx.Wrap(err, "create cache directory")
+	}
+	return &DirCache{dir: dir, now: time.Now}, nil
+}
+
+// path returns the file of key, keys are hashed as they contain URLs.
+func (c *DirCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *DirCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry dirEntry
+	if err = json.Unmarshal(data, &entry); err != nil || !c.now().Before(entry.ExpiresAt) {
+		c.Delete(key)
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+func (c *DirCache) Set(key string, body []byte, ttl time.Duration) {
+	data, err := json.Marshal(&dirEntry{ExpiresAt: c.now().Add(ttl), Body: body})
+	if err != nil {
+		return
+	}
+
+	// 先写临时文件再重命名，避免并发读到写了一半的文件
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+func (c *DirCache) Delete(key string) {
+	_ = os.Remove(c.path(key))
+}
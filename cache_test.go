@@ -0,0 +1,137 @@
+package openseaapi
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+func TestLRUCache(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := NewLRUCache(2)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Minute)
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	// b is the least recently used entry
+	cache.Set("c", []byte("3"), time.Minute)
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.Len())
+
+	cache.Delete("c")
+	assert.Zero(t, cache.Len())
+}
+
+func TestDirCache(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache, err := NewDirCache(t.TempDir())
+	require.NoError(t, err)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("GetCollection||false|api.opensea.io/api/v2/collections/azuki|", []byte(`{"collection":"azuki"}`), time.Minute)
+	body, ok := cache.Get("GetCollection||false|api.opensea.io/api/v2/collections/azuki|")
+	require.True(t, ok)
+	assert.Equal(t, `{"collection":"azuki"}`, string(body))
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("GetCollection||false|api.opensea.io/api/v2/collections/azuki|")
+	assert.False(t, ok)
+}
+
+func TestWithCache(t *testing.T) {
+	var hits atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`{"collection":"azuki"}`))
+	})
+
+	ctx := context.Background()
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithCache(NewLRUCache(10)))
+
+	for i := 0; i < 3; i++ {
+		resp, err := cli.GetCollection(ctx, "azuki")
+		require.NoError(t, err)
+		assert.Equal(t, "azuki", resp.Collection.Collection)
+	}
+	assert.EqualValues(t, 1, hits.Load())
+
+	// another slug and the testnets API are other keys
+	_, err := cli.GetCollection(ctx, "doodles")
+	require.NoError(t, err)
+	_, err = cli.GetCollection(ctx, "azuki", UseTestnets(), UseBaseURL("", srv.URL))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, hits.Load())
+
+	_, err = cli.GetCollection(ctx, "azuki", BypassCache())
+	require.NoError(t, err)
+	_, err = cli.GetCollection(ctx, "azuki", RefreshCache())
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, hits.Load())
+
+	_, err = cli.GetCollection(ctx, "azuki")
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, hits.Load())
+}
+
+func TestWithCacheNeverCachesOrders(t *testing.T) {
+	var hits atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	ctx := context.Background()
+	cli := NewClient(
+		WithBaseURL(srv.URL, ""),
+		WithCache(NewLRUCache(10)),
+		WithCacheTTL(EndpointGetOrder, time.Hour),
+		WithCacheTTL(EndpointCreateListing, time.Hour),
+		WithCacheTTL(EndpointGetCollectionStats, 0),
+	)
+
+	for i := 0; i < 2; i++ {
+		_, err := cli.GetOrder(ctx, &openseamodels.GetOrderPayload{
+			Chain:           chain.Ethereum.Value(),
+			OrderHash:       "0xabc",
+			ProtocolAddress: "0x0000000000000068f116a894984e2db1123eb395",
+		})
+		require.NoError(t, err)
+		_, err = cli.CreateListing(ctx, chain.Ethereum, testCreateOrderPayload())
+		require.NoError(t, err)
+		_, err = cli.GetCollectionStats(ctx, "azuki")
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 6, hits.Load())
+}
+
+func TestWithCacheSkipsErrors(t *testing.T) {
+	var hits atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithCache(NewLRUCache(10)))
+	for i := 0; i < 2; i++ {
+		_, err := cli.GetTraits(context.Background(), "azuki")
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.EqualValues(t, 2, hits.Load())
+}
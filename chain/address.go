@@ -0,0 +1,161 @@
+package chain
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/xTransact/errx/v3"
+)
+
+// solanaAddressLength is the length in bytes of a Solana address, an ed25519 public key.
+const solanaAddressLength = 32
+
+// Address is the address of a wallet or a contract on any chain supported by OpenSea:
+// an EVM hex address, formatted with its EIP-55 checksum, or a Solana base58 address.
+// The zero value is the empty address. Address marshals to and from JSON and text as a plain string.
+// Decoding is lenient so that an address formatted unexpectedly by OpenSea does not fail a whole response:
+// a malformed address is kept as is, see IsValid.
+type Address struct {
+	s string
+	// malformed is set on the addresses decoded from a text which is not an address
+	malformed bool
+}
+
+// ParseAddress parses an EVM hex address, which must be 0x prefixed, or a Solana base58 address.
+func ParseAddress(s string) (Address, error) {
+	// common.IsHexAddress 也接受不带 0x 的地址，要求前缀以免误把其他格式的字符串当作 EVM 地址
+	if (strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")) && common.IsHexAddress(s) {
+		return EVMAddress(common.HexToAddress(s)), nil
+	}
+	if isSolanaAddress(s) {
+		return Address{s: s}, nil
+	}
+	return Address{}, errx.Errorf("invalid address: %q", s)
+}
+
+// ParseAddressFor parses an address of the chain, a Solana base58 address for Solana and Soldev,
+// an EVM hex address otherwise.
+func ParseAddressFor(ch Chain, s string) (Address, error) {
+	a, err := ParseAddress(s)
+	if err != nil {
+		return Address{}, err
+	}
+	if !a.ValidFor(ch) {
+		return Address{}, errx.Errorf("invalid %s address: %q", ch.Value(), s)
+	}
+	return a, nil
+}
+
+// RequireAddress is like ParseAddress but panics if s is not a valid address.
+func RequireAddress(s string) Address {
+	a, err := ParseAddress(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// EVMAddress converts an EVM address.
+func EVMAddress(a common.Address) Address {
+	return Address{s: a.Hex()}
+}
+
+func (a Address) String() string {
+	return a.s
+}
+
+// IsValid reports whether a is an EVM hex address or a Solana base58 address,
+// false for the empty address and the malformed addresses kept by UnmarshalText.
+func (a Address) IsValid() bool {
+	return a.s != "" && !a.malformed
+}
+
+// IsEmpty reports whether a is the zero Address, which is not a valid address.
+// The EVM null address 0x0000000000000000000000000000000000000000 is not empty.
+func (a Address) IsEmpty() bool {
+	return a.s == ""
+}
+
+// IsEVM reports whether a is an EVM hex address.
+func (a Address) IsEVM() bool {
+	return a.IsValid() && strings.HasPrefix(a.s, "0x")
+}
+
+// IsSolana reports whether a is a Solana base58 address.
+func (a Address) IsSolana() bool {
+	return a.IsValid() && !strings.HasPrefix(a.s, "0x")
+}
+
+// EVM returns the EVM address, the null address if a is not an EVM address.
+func (a Address) EVM() common.Address {
+	if !a.IsEVM() {
+		return common.Address{}
+	}
+	return common.HexToAddress(a.s)
+}
+
+// ValidFor reports whether a is an address of the chain.
+func (a Address) ValidFor(ch Chain) bool {
+	if ch.IsSolana() {
+		return a.IsSolana()
+	}
+	return a.IsEVM()
+}
+
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.s), nil
+}
+
+// UnmarshalText parses an EVM hex address or a Solana base58 address, an empty text is the empty address.
+// It never fails: a text which is not an address is kept as is and reported by IsValid,
+// validate the inputs with ParseAddress or ParseAddressFor instead.
+func (a *Address) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*a = Address{}
+		return nil
+	}
+
+	parsed, err := ParseAddress(string(text))
+	if err != nil {
+		*a = Address{s: string(text), malformed: true}
+		return nil
+	}
+	*a = parsed
+	return nil
+}
+
+// IsSolana reports whether the chain is Solana or its devnet, whose addresses are base58 encoded.
+func (c Chain) IsSolana() bool {
+	return c == Solana || c == Soldev
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Radix = big.NewInt(58)
+
+// isSolanaAddress reports whether s is the base58 encoding of 32 bytes.
+func isSolanaAddress(s string) bool {
+	// 32 字节的 base58 编码长度在 32 到 44 之间
+	if len(s) < 32 || len(s) > 44 {
+		return false
+	}
+
+	n := new(big.Int)
+	zeros := 0
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base58Alphabet, s[i])
+		if d < 0 {
+			return false
+		}
+		if d == 0 && n.Sign() == 0 {
+			// 前导的 '1' 表示前导的零字节
+			zeros++
+			continue
+		}
+		n.Mul(n, base58Radix)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+
+	return zeros+len(n.Bytes()) == solanaAddressLength
+}
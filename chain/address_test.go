@@ -0,0 +1,101 @@
+package chain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddress(t *testing.T) {
+	evm, err := ParseAddress("0xed5af388653567af2f388e6224dc7c4b3241c544")
+	require.NoError(t, err)
+	assert.Equal(t, "0xED5AF388653567Af2F388E6224dC7C4b3241C544", evm.String())
+	assert.True(t, evm.IsEVM())
+
+	upper, err := ParseAddress("0XED5AF388653567AF2F388E6224DC7C4B3241C544")
+	require.NoError(t, err)
+	assert.Equal(t, evm, upper)
+	assert.False(t, evm.IsSolana())
+	assert.Equal(t, common.HexToAddress("0xed5af388653567af2f388e6224dc7c4b3241c544"), evm.EVM())
+	assert.Equal(t, evm, EVMAddress(evm.EVM()))
+
+	for _, s := range []string{
+		"So11111111111111111111111111111111111111112",
+		"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+		"11111111111111111111111111111111",
+	} {
+		sol, err := ParseAddress(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, s, sol.String())
+		assert.True(t, sol.IsSolana())
+		assert.Equal(t, common.Address{}, sol.EVM())
+	}
+
+	for _, s := range []string{
+		"",
+		"0xed5af388653567af2f388e6224dc7c4b3241c5",   // too short
+		"ed5af388653567af2f388e6224dc7c4b3241c544",   // no 0x prefix
+		"2345678923456789abcdef2345678923456789ab",   // no 0x prefix, valid base58 but not 32 bytes
+		"So1111111111111111111111111111111111111111", // 31 bytes
+		"So11111111111111111111111111111111111111I2", // I is not in the alphabet
+	} {
+		_, err := ParseAddress(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestAddressValidFor(t *testing.T) {
+	evm := RequireAddress("0xed5af388653567af2f388e6224dc7c4b3241c544")
+	sol := RequireAddress("So11111111111111111111111111111111111111112")
+
+	assert.True(t, evm.ValidFor(Ethereum))
+	assert.False(t, evm.ValidFor(Solana))
+	assert.True(t, sol.ValidFor(Soldev))
+	assert.False(t, sol.ValidFor(Matic))
+	assert.False(t, Address{}.ValidFor(Ethereum))
+
+	_, err := ParseAddressFor(Solana, evm.String())
+	assert.Error(t, err)
+	a, err := ParseAddressFor(Solana, sol.String())
+	require.NoError(t, err)
+	assert.Equal(t, sol, a)
+}
+
+func TestAddressJSON(t *testing.T) {
+	type owner struct {
+		Address Address `json:"address"`
+	}
+
+	var o owner
+	require.NoError(t, json.Unmarshal([]byte(`{"address":"0xed5af388653567af2f388e6224dc7c4b3241c544"}`), &o))
+	assert.Equal(t, "0xED5AF388653567Af2F388E6224dC7C4b3241C544", o.Address.String())
+
+	data, err := json.Marshal(owner{Address: RequireAddress("So11111111111111111111111111111111111111112")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"address":"So11111111111111111111111111111111111111112"}`, string(data))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"address":""}`), &o))
+	assert.True(t, o.Address.IsEmpty())
+
+	// 响应中格式异常的地址原样保留，不影响整个响应的解析
+	require.NoError(t, json.Unmarshal([]byte(`{"address":"not an address"}`), &o))
+	assert.Equal(t, "not an address", o.Address.String())
+	assert.False(t, o.Address.IsValid())
+	assert.False(t, o.Address.IsEVM())
+	assert.False(t, o.Address.IsSolana())
+	assert.False(t, o.Address.ValidFor(Ethereum))
+
+	require.NoError(t, json.Unmarshal([]byte(`{"address":"0x1234"}`), &o))
+	assert.Equal(t, "0x1234", o.Address.String())
+	assert.False(t, o.Address.ValidFor(Ethereum))
+	assert.Equal(t, common.Address{}, o.Address.EVM())
+
+	// 可作为 map 的键
+	m := map[Address]int{RequireAddress("11111111111111111111111111111111"): 1}
+	data, err = json.Marshal(m)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"11111111111111111111111111111111":1}`, string(data))
+}
@@ -2,13 +2,12 @@ package openseaapi
 
 import (
 	"context"
-	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/common"
 	utlsclient "github.com/numblab/utls-client"
 	"github.com/xTransact/errx/v3"
 
@@ -21,35 +20,41 @@ type Servicer interface {
 	/* NFT Endpoints */
 
 	// GetAccount gets an OpenSea Account Profile including details such as bio, social media usernames, and profile image.
-	GetAccount(ctx context.Context, address common.Address,
+	GetAccount(ctx context.Context, address chain.Address,
 		opts ...RequestOptionFn) (resp *openseamodels.Account, err error)
 	// ListNftsByAccount gets NFTs owned by a given account address.
-	ListNftsByAccount(ctx context.Context, ch chain.Chain,
-		payload *openseamodels.GetNftsByAccountPayload) (resp *openseamodels.NftsResponse, err error)
+	ListNftsByAccount(ctx context.Context, ch chain.Chain, payload *openseamodels.GetNftsByAccountPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.NftsResponse, err error)
 	// GetContract gets a smart contract for a given chain and address.
-	GetContract(ctx context.Context, ch chain.Chain, address common.Address) (
-		resp *openseamodels.Contract, err error)
+	GetContract(ctx context.Context, ch chain.Chain, address chain.Address,
+		opts ...RequestOptionFn) (resp *openseamodels.Contract, err error)
 	// ListNftsByContract gets multiple NFTs for a smart contract.
-	ListNftsByContract(ctx context.Context, ch chain.Chain,
-		payload *openseamodels.GetNftsByContractPayload) (resp *openseamodels.NftsResponse, err error)
+	ListNftsByContract(ctx context.Context, ch chain.Chain, payload *openseamodels.GetNftsByContractPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.NftsResponse, err error)
 	// GetNft gets metadata, traits, ownership information, and rarity for a single NFT.
-	GetNft(ctx context.Context, ch chain.Chain,
-		payload *openseamodels.GetNftPayload) (resp *openseamodels.NftResponse, err error)
+	GetNft(ctx context.Context, ch chain.Chain, payload *openseamodels.GetNftPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.NftResponse, err error)
 	// RefreshNftMetadata refreshes metadata for a single NFT.
-	RefreshNftMetadata(ctx context.Context,
-		ch chain.Chain, address common.Address, identifier string) error
+	RefreshNftMetadata(ctx context.Context, ch chain.Chain, address chain.Address, identifier string,
+		opts ...RequestOptionFn) error
+	// ValidateNftMetadata fetches and parses the metadata of a single NFT the way OpenSea would ingest it.
+	ValidateNftMetadata(ctx context.Context, ch chain.Chain, payload *openseamodels.ValidateNftMetadataPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.ValidateNftMetadataResponse, err error)
 	// ListNftsByCollection gets multiple NFTs for a collection.
 	ListNftsByCollection(ctx context.Context, payload *openseamodels.CollectionPayload,
 		opts ...RequestOptionFn) (resp *openseamodels.NftsResponse, err error)
 	// ListCollections gets a list of OpenSea collections.
-	ListCollections(ctx context.Context, payload *openseamodels.ListCollectionsPayload) (
-		resp *openseamodels.CollectionsResponse, err error)
+	ListCollections(ctx context.Context, payload *openseamodels.ListCollectionsPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.CollectionsResponse, err error)
 	// GetCollection gets a single collection including details such as fees, traits, and links.
 	GetCollection(ctx context.Context, collectionSlug string, opts ...RequestOptionFn) (
 		resp *openseamodels.SingleCollection, err error)
 	// GetTraits gets the traits in a collection.
 	GetTraits(ctx context.Context, collectionSlug string, opts ...RequestOptionFn) (
 		resp *openseamodels.Trait, err error)
+	// GetPaymentToken gets a payment token including its symbol, decimals, and ETH and USD prices.
+	GetPaymentToken(ctx context.Context, ch chain.Chain, address chain.Address,
+		opts ...RequestOptionFn) (resp *openseamodels.PaymentToken, err error)
 
 	/*  Analytics Endpoints */
 
@@ -60,8 +65,8 @@ type Servicer interface {
 	ListEventsByAccount(ctx context.Context, payload *openseamodels.GetEventsByAccountPayload,
 		opts ...RequestOptionFn) (resp *openseamodels.AssetEventResponse, err error)
 	// ListEventsByNft gets a list of events for a single NFT. The list will be paginated and include up to 100 events per page.
-	ListEventsByNft(ctx context.Context,
-		payload *openseamodels.GetEventsByNftPayload) (resp *openseamodels.AssetEventResponse, err error)
+	ListEventsByNft(ctx context.Context, payload *openseamodels.GetEventsByNftPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.AssetEventResponse, err error)
 	// ListEventsByCollection gets a list of events for a collection.
 	// The list will be paginated and include up to 100 events per page.
 	ListEventsByCollection(ctx context.Context, payload *openseamodels.GetEventsByCollectionPayload,
@@ -79,17 +84,20 @@ type Servicer interface {
 	CreateCriteriaOffer(ctx context.Context, payload *openseamodels.CreateCriteriaOfferPayload,
 		opts ...RequestOptionFn) (resp *openseamodels.OfferResponse, err error)
 	// CreateIndividualOffer creates an offer to purchase a single NFT (ERC721 or ERC1155).
-	CreateIndividualOffer(ctx context.Context, ch chain.Chain,
-		payload *openseamodels.CreateOrderPayload) (resp *openseamodels.OrderResponse, err error)
+	// An unsigned order is signed by the signer of the client, see WithSigner.
+	CreateIndividualOffer(ctx context.Context, ch chain.Chain, payload *openseamodels.CreateOrderPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.OrderResponse, err error)
 	// CreateListing lists a single NFT (ERC721 or ERC1155) for sale on the OpenSea marketplace.
-	CreateListing(ctx context.Context, ch chain.Chain,
-		payload *openseamodels.CreateOrderPayload) (resp *openseamodels.CreateListingResponse, err error)
+	// An unsigned order is signed by the signer of the client, see WithSigner.
+	CreateListing(ctx context.Context, ch chain.Chain, payload *openseamodels.CreateOrderPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.CreateListingResponse, err error)
 	// FulfillListing retrieves all the information, including signatures, needed to fulfill a listing directly onchain.
-	FulfillListing(ctx context.Context, ch chain.Chain,
-		orderHash, fulfiller string) (resp *openseamodels.FulfillmentDataResponse, err error)
+	FulfillListing(ctx context.Context, ch chain.Chain, orderHash, fulfiller string,
+		opts ...RequestOptionFn) (resp *openseamodels.FulfillmentDataResponse, err error)
 	// FulfillListingWithProtocolAddress retrieves all the information, including signatures, needed to fulfill a listing directly onchain.
 	FulfillListingWithProtocolAddress(ctx context.Context, ch chain.Chain,
-		orderHash, fulfiller, protocolAddress string) (resp *openseamodels.FulfillmentDataResponse, err error)
+		orderHash, fulfiller, protocolAddress string,
+		opts ...RequestOptionFn) (resp *openseamodels.FulfillmentDataResponse, err error)
 	// FulfillOffer retrieves all the information, including signatures, needed to fulfill an offer directly onchain.
 	FulfillOffer(ctx context.Context, payload *openseamodels.FulfillOfferPayload,
 		opts ...RequestOptionFn) (resp *openseamodels.FulfillmentDataResponse, err error)
@@ -101,18 +109,30 @@ type Servicer interface {
 	GetAllCollectionOffers(ctx context.Context, payload *openseamodels.CollectionPayload,
 		opts ...RequestOptionFn) (resp *openseamodels.PageableOffers, err error)
 	// GetIndividualOffers gets the active, valid individual offers. This does not include criteria offers.
-	GetIndividualOffers(ctx context.Context, ch chain.Chain,
-		payload *openseamodels.OrderPayload) (resp *openseamodels.OrdersResponse, err error)
+	GetIndividualOffers(ctx context.Context, ch chain.Chain, payload *openseamodels.OrderPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.OrdersResponse, err error)
 	// GetListings gets the complete set of active, valid listings.
-	GetListings(ctx context.Context, ch chain.Chain,
-		payload *openseamodels.OrderPayload) (resp *openseamodels.OrdersResponse, err error)
+	GetListings(ctx context.Context, ch chain.Chain, payload *openseamodels.OrderPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.OrdersResponse, err error)
 	// GetOrder gets a single order, offer or listing, by its order hash.
 	// Protocol and Chain are required to prevent hash collisions.
-	GetOrder(ctx context.Context, payload *openseamodels.GetOrderPayload) (
-		resp *openseamodels.GetOrderResponse, err error)
+	GetOrder(ctx context.Context, payload *openseamodels.GetOrderPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.GetOrderResponse, err error)
+	// CancelOrder cancels an order off-chain, preventing it from being fulfilled.
+	CancelOrder(ctx context.Context, payload *openseamodels.CancelOrderPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.CancelOrderResponse, err error)
 	// GetTraitOffers gets the active, valid trait offers for the specified collection.
 	GetTraitOffers(ctx context.Context, payload *openseamodels.GetTraitOffersPayload,
 		opts ...RequestOptionFn) (resp *openseamodels.Offers, err error)
+	// GetBestListing gets the cheapest active, valid listing of a single NFT.
+	GetBestListing(ctx context.Context, payload *openseamodels.GetBestListingPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.CollectionListing, err error)
+	// GetBestListings gets the cheapest active, valid listings of a collection, one per NFT and sorted by price.
+	GetBestListings(ctx context.Context, payload *openseamodels.GetBestListingsPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.ListingsByCollectionResponse, err error)
+	// GetBestOffer gets the highest active, valid offer of a single NFT.
+	GetBestOffer(ctx context.Context, payload *openseamodels.GetBestOfferPayload,
+		opts ...RequestOptionFn) (resp *openseamodels.OfferResponse, err error)
 }
 
 type Payloader interface {
@@ -123,6 +143,13 @@ type Payloader interface {
 type client struct {
 	config     *options
 	httpClient *http.Client
+	doer       Doer
+	flights    *flightGroup
+	// paymentTokens caches the bodies of GetPaymentToken, see DefaultPaymentTokenTTL.
+	paymentTokens *LRUCache
+	// signer signs the orders whose signature is empty, see WithSigner and WithPrivateKey.
+	signer    Signer
+	signerErr error
 }
 
 func NewClient(opts ...OptionFn) Servicer {
@@ -136,14 +163,82 @@ func NewClient(opts ...OptionFn) Servicer {
 		timeout = time.Second * 30
 	}
 
-	httpClient := utlsclient.New(
-		utlsclient.WithHost(o.withHost),
-	)
+	var httpClient *http.Client
+	if o.httpClient != nil {
+		cp := *o.httpClient
+		httpClient = &cp
+	} else {
+		httpClient = utlsclient.New(
+			utlsclient.WithHost(o.withHost),
+		)
+	}
+	if o.transport != nil {
+		httpClient.Transport = o.transport
+	}
+	// 自定义的 http.Client 若已设置超时，则仅在显式调用 WithTimeout 时覆盖
+	if o.timeout != 0 || httpClient.Timeout == 0 {
+		httpClient.Timeout = timeout
+	}
+
+	c := &client{
+		config:        o,
+		httpClient:    httpClient,
+		paymentTokens: NewLRUCache(paymentTokenCacheSize),
+		signer:        o.signer,
+	}
+	if c.signer == nil && o.privateKey != "" {
+		c.signer, c.signerErr = NewPrivateKeySigner(o.privateKey)
+	}
+	var doer Doer = DoerFunc(c.send)
+	if o.breaker != nil {
+		doer = c.breaking(doer)
+	}
+	if o.metrics != nil {
+		doer = o.metrics.instrument(doer)
+	}
+	if o.verbose {
+		doer = c.verboseLogging(doer)
+	}
+	doer = chainMiddlewares(doer, o.middlewares)
+	if o.coalesce {
+		c.flights = newFlightGroup()
+		doer = c.coalescing(doer)
+	}
+	// GetPaymentToken 始终使用进程内缓存
+	doer = c.caching(doer)
+	if o.tracerProvider != nil {
+		doer = c.tracing(doer)
+	}
+	c.doer = doer
+
+	return c
+}
+
+// baseURL resolves the API base URL for the environment selected by the request options.
+// Per-request overrides take precedence over the client configuration,
+// which in turn takes precedence over the OpenSea defaults.
+func (c *client) baseURL(o *requestOptions) string {
+	var candidates []string
+	if o.testnets {
+		candidates = []string{o.testnetsBaseURL, c.config.testnetsBaseURL}
+	} else {
+		candidates = []string{o.baseURL, c.config.baseURL}
+	}
+
+	for _, u := range candidates {
+		if u != "" {
+			return strings.TrimRight(u, "/")
+		}
+	}
+
+	return openseaapiutils.GetBaseURL(o.testnets)
+}
 
-	return &client{
-		config:     o,
-		httpClient: httpClient,
+func (c *client) logger() *slog.Logger {
+	if c.config.logger != nil {
+		return c.config.logger
 	}
+	return slog.Default()
 }
 
 func (c *client) challenge(r *http.Request) {
@@ -160,51 +255,65 @@ func (c *client) contentTypeJson(r *http.Request) {
 	r.Header.Set("Content-Type", "application/json")
 }
 
-func (c *client) doRequest(r *http.Request, testnets bool) ([]byte, error) {
-	if testnets {
+// validateAddress checks that the address belongs to the chain, e.g. a base58 address for chain.Solana.
+func validateAddress(ch chain.Chain, address chain.Address) error {
+	if address.IsEmpty() {
+		return errx.New("invalid address")
+	}
+	if !address.ValidFor(ch) {
+		return errx.Errorf("invalid %s address: %s", ch.Value(), address)
+	}
+	return nil
+}
+
+// retryPolicy resolves the retry policy of a request, preferring the per-request policy.
+func (c *client) retryPolicy(o *requestOptions) RetryPolicy {
+	if o.retryPolicy != nil {
+		return o.retryPolicy
+	}
+	if c.config.retryPolicy != nil {
+		return c.config.retryPolicy
+	}
+	return DefaultRetryPolicy()
+}
+
+func (c *client) doRequest(r *http.Request, o *requestOptions) ([]byte, error) {
+	if o.testnets {
 		// 测试网下关掉长链接
 		r.Header.Set("Connection", "close")
 	} else {
 		// 测试网不需要 API Key，但是主网需要
 		c.challenge(r)
+		if o.apiKey != "" {
+			r.Header.Set("x-api-key", o.apiKey)
+		}
 	}
-
-	res, err := doWithRetry(c.httpClient, r, 5, time.Second)
-	if err != nil {
-		return nil, errx.WithStack(err)
+	for key, values := range o.header {
+		for _, v := range values {
+			r.Header.Add(key, v)
+		}
 	}
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, errx.Wrapf(err, "%s: read response body", res.Status)
+	if o.timeout > 0 {
+		ctx, cancel := context.WithTimeout(r.Context(), o.timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return nil, openseaapiutils.ParseFailureResponse(res, body)
+	req := &Request{
+		Endpoint:    o.endpoint,
+		Chain:       o.chain,
+		Testnets:    o.testnets,
+		Payload:     o.payload,
+		HTTPRequest: r,
+		options:     o,
 	}
-
-	return body, nil
-}
-
-// shouldRetry 判断是否需要进行重试
-// 当前仅针对 429 StatusTooManyRequests 进行重试
-func shouldRetry(err error, resp *http.Response) bool {
-	return resp != nil && resp.StatusCode == http.StatusTooManyRequests
-}
-
-func doWithRetry(cli *http.Client, req *http.Request, max int, interval time.Duration) (resp *http.Response, err error) {
-	for i := 0; i < max; i++ {
-		resp, err = cli.Do(req)
-		err = errx.WithStack(err)
-		if !shouldRetry(err, resp) {
-			return
-		}
-		slog.Warn("[OpenSea API] Failed to do http request, attempting retry...",
-			"attempts", i+1,
-			"err", err,
-			"status", resp.Status)
-		time.Sleep(interval)
+	resp, err := c.doer.Do(req)
+	if o.responseMeta != nil {
+		o.responseMeta.fill(req, resp)
+	}
+	if err != nil {
+		return nil, errx.WithStack(err)
 	}
-	return
+
+	return resp.Body, nil
 }
@@ -0,0 +1,147 @@
+package openseaapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestWithBaseURL(t *testing.T) {
+	var paths []string
+	mainnet := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, "mainnet:"+r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	})
+	testnets := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, "testnets:"+r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	ctx := context.Background()
+	cli := NewClient(WithBaseURL(mainnet.URL+"/", testnets.URL))
+
+	_, err := cli.GetCollection(ctx, "azuki")
+	require.NoError(t, err)
+
+	_, err = cli.GetCollection(ctx, "azuki", UseTestnets())
+	require.NoError(t, err)
+
+	_, err = cli.GetNft(ctx, chain.Sepolia, &openseamodels.GetNftPayload{
+		Address:    chain.RequireAddress("0xb31d6b5516eed64a874e9f7ab605e359e20b645f"),
+		Identifier: "1",
+	})
+	require.NoError(t, err)
+
+	// per-request overrides win over the client configuration
+	_, err = cli.GetTraits(ctx, "azuki", UseBaseURL(testnets.URL, ""))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"mainnet:/api/v2/collections/azuki",
+		"testnets:/api/v2/collections/azuki",
+		"testnets:/api/v2/chain/sepolia/contract/0xB31D6B5516Eed64a874E9F7aB605e359e20B645F/nfts/1",
+		"testnets:/api/v2/traits/azuki",
+	}, paths)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWithTransport(t *testing.T) {
+	var gotURL string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		gotURL = r.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(`{"username":"alice"}`)),
+			Request:    r,
+		}, nil
+	})
+
+	cli := NewClient(WithTransport(transport))
+	resp, err := cli.GetAccount(context.Background(), chain.RequireAddress("0x69493301a10A06679a6771D33E8CDd3a5fdA4dB4"))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", resp.Username)
+	assert.Equal(t, "https://api.opensea.io/api/v2/accounts/0x69493301a10A06679a6771D33E8CDd3a5fdA4dB4", gotURL)
+}
+
+func TestWithTimeout(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	httpClient := &http.Client{Timeout: time.Minute}
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithHTTPClient(httpClient), WithTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := cli.GetCollection(context.Background(), "azuki", UseRetryPolicy(NoRetryPolicy()))
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	// the caller's client must not be mutated
+	assert.Equal(t, time.Minute, httpClient.Timeout)
+}
+
+func TestRequestOptions(t *testing.T) {
+	var got []http.Header
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Clone())
+		if r.URL.Path == "/api/v2/chain/ethereum/contract/0x0000000000000000000000000000000000000001/nfts/1" {
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	ctx := context.Background()
+	cli := NewClient(WithApiKey("client-key"))
+	address := chain.RequireAddress("0xb31d6b5516eed64a874e9f7ab605e359e20b645f")
+
+	// the chain scoped endpoints take the per-call options too
+	_, err := cli.GetContract(ctx, chain.Ethereum, address,
+		UseBaseURL(srv.URL, ""), UseApiKey("call-key"), UseHeader("X-Trace", "a"), UseHeader("X-Trace", "b"))
+	require.NoError(t, err)
+	_, err = cli.GetOrder(ctx, &openseamodels.GetOrderPayload{
+		Chain:           chain.Ethereum.Value(),
+		OrderHash:       "0xabc",
+		ProtocolAddress: "0x0000000000000068f116a894984e2db1123eb395",
+	}, UseBaseURL(srv.URL, ""))
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "call-key", got[0].Get("x-api-key"))
+	assert.Equal(t, []string{"a", "b"}, got[0].Values("X-Trace"))
+	assert.Equal(t, "client-key", got[1].Get("x-api-key"))
+	assert.Empty(t, got[1].Values("X-Trace"))
+
+	start := time.Now()
+	_, err = cli.GetNft(ctx, chain.Ethereum, &openseamodels.GetNftPayload{
+		Address:    chain.RequireAddress("0x0000000000000000000000000000000000000001"),
+		Identifier: "1",
+	}, UseBaseURL(srv.URL, ""), UseTimeout(50*time.Millisecond), UseRetryPolicy(NoRetryPolicy()))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
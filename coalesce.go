@@ -0,0 +1,138 @@
+package openseaapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/xTransact/errx/v3"
+)
+
+// flightGroup coalesces identical concurrent GET calls into a single http call, see WithCoalescing.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// flightCall is an http call shared by several waiters.
+type flightCall struct {
+	done chan struct{}
+	// cancel aborts the call once every waiter has given up on it.
+	cancel  context.CancelFunc
+	waiters int
+	// deadline is the deadline of the caller starting the call, zero when it has none.
+	deadline time.Time
+
+	req  *Request
+	resp *Response
+	err  error
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+// flightKey identifies identical calls by their method, URL, query and environment,
+// and by the API key and headers set on the call through UseApiKey and UseHeader.
+func flightKey(req *Request) string {
+	key := req.HTTPRequest.Method + " " + req.HTTPRequest.URL.String() + " testnets=" + strconv.FormatBool(req.Testnets)
+	if o := req.options; o != nil {
+		// 单次请求的 key 和 header 可能改变响应，不同的调用不能共享
+		key += " key=" + o.apiKey + " header=" + url.Values(o.header).Encode()
+	}
+	return key
+}
+
+// covers reports whether the call lives as long as a waiter with the given context needs:
+// the call keeps the deadline of the caller starting it, a waiter with a later deadline starts its own call.
+func (call *flightCall) covers(ctx context.Context) bool {
+	if call.deadline.IsZero() {
+		return true
+	}
+	deadline, ok := ctx.Deadline()
+	return ok && !deadline.After(call.deadline)
+}
+
+// coalescing shares the in-flight call between the identical concurrent GET calls going through the doer.
+// The shared call outlives the cancellation of any of its waiters and is only aborted when all of them gave up.
+func (c *client) coalescing(next Doer) Doer {
+	group := c.flights
+
+	return DoerFunc(func(req *Request) (*Response, error) {
+		if req.HTTPRequest.Method != http.MethodGet {
+			return next.Do(req)
+		}
+
+		key := flightKey(req)
+		ctx := req.Context()
+
+		group.mu.Lock()
+		call, shared := group.calls[key]
+		if shared && !call.covers(ctx) {
+			shared = false
+		}
+		if !shared {
+			// 共享的请求不能继承发起者的 context，否则发起者取消会连累其他等待者，
+			// 但保留其截止时间，重试与限流器据此放弃等待
+			var (
+				callCtx context.Context
+				cancel  context.CancelFunc
+			)
+			deadline, ok := ctx.Deadline()
+			if ok {
+				callCtx, cancel = context.WithDeadline(context.WithoutCancel(ctx), deadline)
+			} else {
+				callCtx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+			}
+			call = &flightCall{done: make(chan struct{}), cancel: cancel, deadline: deadline}
+			cp := *req
+			cp.HTTPRequest = req.HTTPRequest.WithContext(callCtx)
+			call.req = &cp
+			group.calls[key] = call
+
+			go func() {
+				defer cancel()
+				call.resp, call.err = next.Do(call.req)
+
+				group.mu.Lock()
+				if group.calls[key] == call {
+					delete(group.calls, key)
+				}
+				group.mu.Unlock()
+				close(call.done)
+			}()
+		}
+		call.waiters++
+		group.mu.Unlock()
+
+		if shared && c.config.metrics != nil {
+			c.config.metrics.observeCoalesced(req)
+		}
+
+		select {
+		case <-call.done:
+			req.attempts = call.req.attempts
+			if call.resp == nil {
+				return nil, call.err
+			}
+			resp := *call.resp
+			resp.Header = call.resp.Header.Clone()
+			return &resp, call.err
+		case <-ctx.Done():
+			group.mu.Lock()
+			call.waiters--
+			if call.waiters == 0 {
+				// 没有等待者了，中止请求，后续相同的调用重新发起
+				call.cancel()
+				if group.calls[key] == call {
+					delete(group.calls, key)
+				}
+			}
+			group.mu.Unlock()
+			return nil, errx.WithStack(ctx.Err())
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package openseaapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCoalescing(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`{"collection":"azuki"}`))
+	})
+
+	metrics := NewMetricsCollector("")
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithCoalescing(), WithMetrics(metrics))
+	saved := metrics.coalesced.WithLabelValues(EndpointGetCollection, "", "false")
+
+	const callers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := cli.GetCollection(context.Background(), "azuki")
+			if err == nil && resp.Collection.Collection != "azuki" {
+				err = assert.AnError
+			}
+			errs <- err
+		}()
+	}
+
+	require.Eventually(t, func() bool { return testutil.ToFloat64(saved) == callers-1 },
+		time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 1, hits.Load())
+
+	// the call is forgotten once done
+	_, err := cli.GetCollection(context.Background(), "azuki")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, hits.Load())
+}
+
+func TestCoalescingWaiterCancellation(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`{"collection":"azuki"}`))
+	})
+
+	metrics := NewMetricsCollector("")
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithCoalescing(), WithMetrics(metrics))
+	saved := metrics.coalesced.WithLabelValues(EndpointGetCollection, "", "false")
+
+	// the first caller starts the call then gives up, the second one still gets the response
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := cli.GetCollection(ctx, "azuki")
+		first <- err
+	}()
+	require.Eventually(t, func() bool { return hits.Load() == 1 }, time.Second, time.Millisecond)
+
+	second := make(chan error, 1)
+	go func() {
+		_, err := cli.GetCollection(context.Background(), "azuki")
+		second <- err
+	}()
+	require.Eventually(t, func() bool { return testutil.ToFloat64(saved) == 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-first, context.Canceled)
+
+	close(release)
+	require.NoError(t, <-second)
+	assert.EqualValues(t, 1, hits.Load())
+}
+
+func TestCoalescingAbortsWithoutWaiters(t *testing.T) {
+	aborted := make(chan struct{})
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(aborted)
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithCoalescing())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := cli.GetCollection(ctx, "azuki")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Fatal("the shared call was not aborted")
+	}
+}
+
+func TestCoalescingKeepsDeadline(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`{"collection":"azuki"}`))
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithCoalescing(), WithRetryPolicy(NoRetryPolicy()))
+	flights := cli.(*client).flights
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	results := make(chan error, 3)
+	get := func(ctx context.Context) {
+		_, err := cli.GetCollection(ctx, "azuki")
+		results <- err
+	}
+
+	go get(ctx)
+	require.Eventually(t, func() bool { return hits.Load() == 1 }, time.Second, time.Millisecond)
+	flights.mu.Lock()
+	for _, call := range flights.calls {
+		deadline, ok := call.req.Context().Deadline()
+		require.True(t, ok)
+		expected, _ := ctx.Deadline()
+		assert.Equal(t, expected, deadline)
+	}
+	flights.mu.Unlock()
+
+	// an earlier deadline is covered by the shared call, no deadline or a later one starts another call
+	shorter, cancelShorter := context.WithTimeout(ctx, 30*time.Second)
+	defer cancelShorter()
+	go get(shorter)
+	require.Eventually(t, func() bool { return flightWaiters(flights) == 2 }, time.Second, time.Millisecond)
+	go get(context.Background())
+	require.Eventually(t, func() bool { return hits.Load() == 2 && flightWaiters(flights) == 1 },
+		time.Second, time.Millisecond)
+
+	close(release)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, <-results)
+	}
+	assert.EqualValues(t, 2, hits.Load())
+}
+
+func TestCoalescingPerRequestKeyAndHeaders(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`{"collection":"azuki"}`))
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithApiKey("default"), WithCoalescing())
+
+	opts := [][]RequestOptionFn{
+		nil,
+		{UseApiKey("other")},
+		{UseHeader("X-Request-Id", "1")},
+		{UseHeader("X-Request-Id", "2")},
+		{UseHeader("X-Request-Id", "2")},
+	}
+	results := make(chan error, len(opts))
+	for _, o := range opts {
+		go func(o []RequestOptionFn) {
+			_, err := cli.GetCollection(context.Background(), "azuki", o...)
+			results <- err
+		}(o)
+	}
+	flights := cli.(*client).flights
+	require.Eventually(t, func() bool { return hits.Load() == 4 && flightWaiters(flights) == len(opts) },
+		time.Second, time.Millisecond)
+
+	close(release)
+	for range opts {
+		require.NoError(t, <-results)
+	}
+	assert.EqualValues(t, 4, hits.Load())
+}
+
+// flightWaiters counts the waiters of the in-flight calls, the calls replaced in the group excluded.
+func flightWaiters(g *flightGroup) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	n := 0
+	for _, call := range g.calls {
+		n += call.waiters
+	}
+	return n
+}
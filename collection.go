@@ -9,35 +9,51 @@ import (
 	"github.com/xTransact/errx/v3"
 
 	"github.com/xTransact/openseaapi/chain"
-	"github.com/xTransact/openseaapi/openseaapiutils"
 	"github.com/xTransact/openseaapi/openseamodels"
 )
 
 // ListCollections gets a list of OpenSea collections.
-// @param chain_identifier: The blockchain on which to filter the results. e.g., ethereum
+// @param chain_identifier: The blockchain on which to filter the results. e.g., ethereum. Empty for all chains.
 // @param include_hidden: If true, will return hidden collections. Default: false
 // @param limit: The number of collections to return. Must be between 1 and 100. Default: 100
 // @param next: The cursor for the next page of results. This is returned from a previous request.
+// @param order_by: The order in which to sort the collections. Default: created_date
+// @param creator_username: Filter to only include collections created by the given OpenSea username.
+// The Category, SafelistStatus and Nsfw filters of the payload are applied locally on the returned page.
 // DOC: https://docs.opensea.io/reference/list_collections
-func (c *client) ListCollections(ctx context.Context, payload *openseamodels.ListCollectionsPayload) (
-	resp *openseamodels.CollectionsResponse, err error) {
+func (c *client) ListCollections(ctx context.Context, payload *openseamodels.ListCollectionsPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.CollectionsResponse, err error) {
 
 	if err = payload.Validate(); err != nil {
 		return nil, err
 	}
 
-	ch := chain.RequireFromString(payload.ChainIdentifier)
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	// 未指定 chain 时列出所有链的 collection，使用请求选项中的环境
+	if payload.ChainIdentifier != "" {
+		ch := chain.RequireFromString(payload.ChainIdentifier)
+		o.testnets, o.chain = ch.IsTestNet(), ch
+	}
+	o.endpoint, o.payload = EndpointListCollections, payload
 
 	// GET /api/v2/collections
-	url := fmt.Sprintf("%s/api/v2/collections", openseaapiutils.GetBaseURLByChain(ch))
+	url := fmt.Sprintf("%s/api/v2/collections", c.baseURL(o))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
 
+	qs := payload.ToQuery()
+	if len(qs) > 0 {
+		req.URL.RawQuery = qs.Encode()
+	}
+
 	c.acceptJson(req)
-	body, err := c.doRequest(req, ch.IsTestNet())
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -47,6 +63,14 @@ func (c *client) ListCollections(ctx context.Context, payload *openseamodels.Lis
 		return nil, errx.Wrap(err, "unmarshal response body")
 	}
 
+	collections := resp.Collections[:0]
+	for _, collection := range resp.Collections {
+		if collection != nil && payload.Match(collection) {
+			collections = append(collections, collection)
+		}
+	}
+	resp.Collections = collections
+
 	return resp, nil
 }
 
@@ -61,9 +85,10 @@ func (c *client) GetCollection(ctx context.Context, collectionSlug string, opts
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointGetCollection, collectionSlug
 
 	// GET /api/v2/collections/{collection_slug}
-	url := fmt.Sprintf("%s/api/v2/collections/%s", openseaapiutils.GetBaseURL(o.testnets), collectionSlug)
+	url := fmt.Sprintf("%s/api/v2/collections/%s", c.baseURL(o), collectionSlug)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -71,7 +96,7 @@ func (c *client) GetCollection(ctx context.Context, collectionSlug string, opts
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, o.testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -93,9 +118,10 @@ func (c *client) GetCollectionStats(ctx context.Context, collectionSlug string,
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointGetCollectionStats, collectionSlug
 
 	// GET /api/v2/collections/{collection_slug}/stats
-	url := fmt.Sprintf("%s/api/v2/collections/%s/stats", openseaapiutils.GetBaseURL(o.testnets), collectionSlug)
+	url := fmt.Sprintf("%s/api/v2/collections/%s/stats", c.baseURL(o), collectionSlug)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -103,7 +129,7 @@ func (c *client) GetCollectionStats(ctx context.Context, collectionSlug string,
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, o.testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
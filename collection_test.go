@@ -0,0 +1,71 @@
+package openseaapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/openseaenums"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+func TestListCollections(t *testing.T) {
+	var query url.Values
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{"collections":[
+			{"collection":"a","category":"pfps","safelist_status":"verified","is_nsfw":false},
+			{"collection":"b","category":"art","safelist_status":"verified","is_nsfw":false},
+			{"collection":"c","category":"PFPs","safelist_status":"not_requested","is_nsfw":false},
+			{"collection":"d","category":"pfps","safelist_status":"approved","is_nsfw":true}
+		],"next":"cursor-2"}`))
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""))
+	nsfw := false
+	resp, err := cli.ListCollections(context.Background(), &openseamodels.ListCollectionsPayload{
+		ChainIdentifier: "ethereum",
+		Limit:           50,
+		Next:            "cursor-1",
+		OrderBy:         openseamodels.CollectionsOrderBySevenDayVolume,
+		CreatorUsername: "alice",
+		Category:        "pfps",
+		SafelistStatus:  []openseaenums.SafelistStatus{openseaenums.SafelistStatusVerified, openseaenums.SafelistStatusApproved},
+		Nsfw:            &nsfw,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, url.Values{
+		"chain_identifier": {"ethereum"},
+		"limit":            {"50"},
+		"next":             {"cursor-1"},
+		"order_by":         {"seven_day_volume"},
+		"creator_username": {"alice"},
+	}, query)
+	require.Len(t, resp.Collections, 1)
+	assert.Equal(t, "a", resp.Collections[0].Collection)
+	assert.Equal(t, "cursor-2", resp.Next)
+}
+
+func TestListCollectionsAllChains(t *testing.T) {
+	var query url.Values
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{"collections":[{"collection":"a"}]}`))
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""))
+	resp, err := cli.ListCollections(context.Background(), &openseamodels.ListCollectionsPayload{})
+	require.NoError(t, err)
+	assert.Empty(t, query)
+	assert.Len(t, resp.Collections, 1)
+
+	_, err = cli.ListCollections(context.Background(), &openseamodels.ListCollectionsPayload{OrderBy: "name"})
+	require.Error(t, err)
+	_, err = cli.ListCollections(context.Background(), &openseamodels.ListCollectionsPayload{ChainIdentifier: "unknown"})
+	require.Error(t, err)
+}
@@ -6,12 +6,9 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/ethereum/go-ethereum/common"
-
 	"github.com/xTransact/errx/v3"
 
 	"github.com/xTransact/openseaapi/chain"
-	"github.com/xTransact/openseaapi/openseaapiutils"
 	"github.com/xTransact/openseaapi/openseamodels"
 )
 
@@ -19,16 +16,23 @@ import (
 // @param ch (chain.Chain): required: The blockchain on which to filter the results.
 // @param address: required: The unique public blockchain identifier for the contract.
 // DOC: https://docs.opensea.io/reference/get_contract
-func (c *client) GetContract(ctx context.Context, ch chain.Chain, address common.Address) (
-	resp *openseamodels.Contract, err error) {
+func (c *client) GetContract(ctx context.Context, ch chain.Chain, address chain.Address,
+	opts ...RequestOptionFn) (resp *openseamodels.Contract, err error) {
+
+	if err = validateAddress(ch, address); err != nil {
+		return nil, err
+	}
 
-	if !common.IsHexAddress(address.String()) {
-		return nil, errx.New("invalid address")
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
 	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointGetContract, ch, address
 
 	// GET /api/v2/chain/{chain}/contract/{address}
 	url := fmt.Sprintf("%s/api/v2/chain/%s/contract/%s",
-		openseaapiutils.GetBaseURLByChain(ch), ch.Value(), address.String())
+		c.baseURL(o), ch.Value(), address.String())
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -36,7 +40,7 @@ func (c *client) GetContract(ctx context.Context, ch chain.Chain, address common
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, ch.IsTestNet())
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -0,0 +1,79 @@
+package openseaapi
+
+import (
+	"context"
+
+	"github.com/xTransact/errx/v3"
+
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+// DefaultCrawlBuffer is the number of items buffered by a Crawler when none is given, one page of 200 items.
+const DefaultCrawlBuffer = 200
+
+// Crawler streams the items of an Iterator over a channel, fetching the next pages in the background
+// while the caller handles the buffered items. At most buffer items are fetched ahead of the caller.
+// Since a page cursor is only known once the previous page is received, pages are fetched one at a time;
+// the calls go through the client and so respect its rate limiter.
+//
+//	crawler := CrawlNftsByCollection(ctx, cli, payload, 0)
+//	for nft := range crawler.Items() {
+//		...
+//	}
+//	if err := crawler.Err(); err != nil {
+//		...
+//	}
+type Crawler[T any] struct {
+	items chan T
+	done  chan struct{}
+	err   error
+}
+
+// Crawl starts crawling the iterator in the background, see Crawler.
+// The crawl stops at the end of the iterator, on error, or when ctx is done.
+func Crawl[T any](ctx context.Context, it *Iterator[T], buffer int) *Crawler[T] {
+	if buffer <= 0 {
+		buffer = DefaultCrawlBuffer
+	}
+
+	c := &Crawler[T]{
+		items: make(chan T, buffer),
+		done:  make(chan struct{}),
+	}
+	go c.run(ctx, it)
+	return c
+}
+
+func (c *Crawler[T]) run(ctx context.Context, it *Iterator[T]) {
+	defer close(c.done)
+	defer close(c.items)
+
+	for it.Next() {
+		select {
+		case c.items <- it.Item():
+		case <-ctx.Done():
+			c.err = errx.WithStack(ctx.Err())
+			return
+		}
+	}
+	c.err = it.Err()
+}
+
+// Items returns the channel of the crawled items, closed when the crawl stops.
+func (c *Crawler[T]) Items() <-chan T {
+	return c.items
+}
+
+// Err waits for the crawl to stop and returns the error which stopped it, nil when every page was crawled.
+// The caller must drain Items or cancel the context of the crawl before calling Err.
+func (c *Crawler[T]) Err() error {
+	<-c.done
+	return c.err
+}
+
+// CrawlNftsByCollection crawls ListNftsByCollection, buffering at most buffer NFTs, DefaultCrawlBuffer if <= 0.
+func CrawlNftsByCollection(ctx context.Context, s Servicer, payload *openseamodels.CollectionPayload,
+	buffer int, opts ...RequestOptionFn) *Crawler[*openseamodels.Nft] {
+
+	return Crawl(ctx, IterNftsByCollection(ctx, s, payload, opts...), buffer)
+}
@@ -0,0 +1,82 @@
+package openseaapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+// newNftPagesServer serves pages of 2 NFTs of the collection through the cursors "", "1", "2"...
+func newNftPagesServer(t *testing.T, pages int, fetched *atomic.Int32) string {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fetched.Add(1)
+		n, _ := strconv.Atoi(r.URL.Query().Get("next"))
+		next := ""
+		if n+1 < pages {
+			next = strconv.Itoa(n + 1)
+		}
+		_, _ = w.Write([]byte(`{"nfts":[{"identifier":"` + strconv.Itoa(2*n) + `"},{"identifier":"` +
+			strconv.Itoa(2*n+1) + `"}],"next":"` + next + `"}`))
+	})
+	return srv.URL
+}
+
+func TestCrawlNftsByCollection(t *testing.T) {
+	var fetched atomic.Int32
+	cli := NewClient(WithBaseURL(newNftPagesServer(t, 5, &fetched), ""))
+
+	crawler := CrawlNftsByCollection(context.Background(), cli,
+		&openseamodels.CollectionPayload{CollectionSlug: "azuki"}, 2)
+
+	// the crawler prefetches up to the buffer while the caller is busy
+	require.Eventually(t, func() bool { return fetched.Load() == 2 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.EqualValues(t, 2, fetched.Load())
+
+	var ids []string
+	for nft := range crawler.Items() {
+		ids = append(ids, nft.Identifier)
+	}
+	require.NoError(t, crawler.Err())
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, ids)
+	assert.EqualValues(t, 5, fetched.Load())
+}
+
+func TestCrawlerCancellation(t *testing.T) {
+	var fetched atomic.Int32
+	cli := NewClient(WithBaseURL(newNftPagesServer(t, 1000, &fetched), ""))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	crawler := CrawlNftsByCollection(ctx, cli, &openseamodels.CollectionPayload{CollectionSlug: "azuki"}, 1)
+
+	<-crawler.Items()
+	cancel()
+	for range crawler.Items() {
+	}
+	require.ErrorIs(t, crawler.Err(), context.Canceled)
+	assert.Less(t, fetched.Load(), int32(10))
+}
+
+func TestCrawlerRespectsRateLimit(t *testing.T) {
+	var fetched atomic.Int32
+	cli := NewClient(WithBaseURL(newNftPagesServer(t, 3, &fetched), ""), WithRateLimit(2, 0))
+
+	start := time.Now()
+	crawler := CrawlNftsByCollection(context.Background(), cli, &openseamodels.CollectionPayload{CollectionSlug: "azuki"}, 0)
+	count := 0
+	for range crawler.Items() {
+		count++
+	}
+	require.NoError(t, crawler.Err())
+	assert.Equal(t, 6, count)
+	// the burst lets the first two requests through, the third one waits 500ms
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
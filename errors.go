@@ -0,0 +1,154 @@
+package openseaapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+)
+
+// Sentinel errors matched by *APIError through errors.Is, e.g. errors.Is(err, ErrNotFound).
+var (
+	// ErrBadRequest matches 400 Bad Request responses, e.g. invalid payloads or signatures.
+	ErrBadRequest = errors.New("opensea: bad request")
+	// ErrUnauthorized matches 401 Unauthorized and 403 Forbidden responses, e.g. a missing or invalid API key.
+	ErrUnauthorized = errors.New("opensea: unauthorized")
+	// ErrNotFound matches 404 Not Found responses.
+	ErrNotFound = errors.New("opensea: not found")
+	// ErrRateLimited matches 429 Too Many Requests responses.
+	ErrRateLimited = errors.New("opensea: rate limited")
+	// ErrServer matches 5xx responses.
+	ErrServer = errors.New("opensea: server error")
+)
+
+// APIError is returned by every Servicer method when OpenSea answers with a non-200 status.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response, e.g. 404
+	StatusCode int
+	// Status is the HTTP status of the response, e.g. "404 Not Found"
+	Status string
+	// Errors is the `errors` array of the OpenSea error response.
+	Errors []string
+	// Method is the HTTP method of the request.
+	Method string
+	// URL is the URL of the request, including the query.
+	URL string
+	// Header is the header of the response.
+	Header http.Header
+	// Body is the raw body of the response.
+	Body []byte
+}
+
+type errorResponse struct {
+	Errors []json.RawMessage `json:"errors"`
+}
+
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Header:     resp.Header,
+		Body:       body,
+	}
+
+	if resp.Request != nil {
+		req = resp.Request
+	}
+	if req != nil {
+		e.Method = req.Method
+		e.URL = req.URL.String()
+	}
+
+	var er errorResponse
+	if err := json.Unmarshal(body, &er); err == nil {
+		for _, raw := range er.Errors {
+			var msg string
+			if err := json.Unmarshal(raw, &msg); err != nil {
+				msg = string(raw)
+			}
+			e.Errors = append(e.Errors, msg)
+		}
+	}
+
+	return e
+}
+
+func (e *APIError) Error() string {
+	var detail string
+	switch {
+	case len(e.Errors) > 0:
+		detail = strings.Join(e.Errors, "; ")
+	case len(e.Body) > 0:
+		detail = string(e.Body)
+	default:
+		detail = "unexpected http response"
+	}
+
+	if e.Method == "" {
+		return fmt.Sprintf("%s: %s", e.Status, detail)
+	}
+	return fmt.Sprintf("%s %s: %s: %s", e.Method, e.URL, e.Status, detail)
+}
+
+// Is makes the error match the sentinel error of its status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// Retryable reports whether sending the same request again may succeed.
+func (e *APIError) Retryable() bool {
+	return isRetryableStatus(e.StatusCode)
+}
+
+// IsRetryable reports whether err is worth retrying:
+// throttled and 5xx responses, timeouts and connection resets.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable()
+	}
+
+	return isRetryableTransportError(err)
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+func isRetryableTransportError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
@@ -0,0 +1,56 @@
+package openseaapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":["Collection not found"]}`))
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""))
+	_, err := cli.GetCollection(context.Background(), "unknown")
+	require.Error(t, err)
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrRateLimited))
+	assert.False(t, IsRetryable(err))
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, []string{"Collection not found"}, apiErr.Errors)
+	assert.Equal(t, http.MethodGet, apiErr.Method)
+	assert.Equal(t, srv.URL+"/api/v2/collections/unknown", apiErr.URL)
+	assert.Equal(t, "req-1", apiErr.Header.Get("X-Request-Id"))
+}
+
+func TestAPIErrorClassification(t *testing.T) {
+	cases := []struct {
+		status    int
+		sentinel  error
+		retryable bool
+	}{
+		{http.StatusBadRequest, ErrBadRequest, false},
+		{http.StatusUnauthorized, ErrUnauthorized, false},
+		{http.StatusForbidden, ErrUnauthorized, false},
+		{http.StatusNotFound, ErrNotFound, false},
+		{http.StatusTooManyRequests, ErrRateLimited, true},
+		{http.StatusBadGateway, ErrServer, true},
+	}
+
+	for _, c := range cases {
+		err := &APIError{StatusCode: c.status, Status: http.StatusText(c.status)}
+		assert.True(t, errors.Is(err, c.sentinel), c.status)
+		assert.Equal(t, c.retryable, IsRetryable(err), c.status)
+	}
+}
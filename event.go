@@ -8,7 +8,6 @@ import (
 
 	"github.com/xTransact/errx/v3"
 
-	"github.com/xTransact/openseaapi/openseaapiutils"
 	"github.com/xTransact/openseaapi/openseamodels"
 )
 
@@ -21,24 +20,32 @@ func (c *client) ListEventsByAccount(ctx context.Context, payload *openseamodels
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointListEventsByAccount, payload
 
 	// GET /api/v2/events/accounts/{address}
 	url := fmt.Sprintf("%s/api/v2/events/accounts/%s",
-		openseaapiutils.GetBaseURL(o.testnets), payload.Address)
+		c.baseURL(o), payload.Address)
 
-	return c.getEvents(ctx, payload, o.testnets, url)
+	return c.getEvents(ctx, payload, o, url)
 }
 
 // ListEventsByNft gets a list of events for a single NFT. The list will be paginated and include up to 100 events per page.
 // DOC: https://docs.opensea.io/reference/list_events_by_nft
-func (c *client) ListEventsByNft(ctx context.Context,
-	payload *openseamodels.GetEventsByNftPayload) (resp *openseamodels.AssetEventResponse, err error) {
+func (c *client) ListEventsByNft(ctx context.Context, payload *openseamodels.GetEventsByNftPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.AssetEventResponse, err error) {
+
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = payload.Chain.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointListEventsByNft, payload.Chain, payload
 
 	// GET /api/v2/events/chain/{chain}/contract/{address}/nfts/{identifier}
 	url := fmt.Sprintf("%s/api/v2/events/chain/%s/contract/%s/nfts/%s",
-		openseaapiutils.GetBaseURLByChain(payload.Chain), payload.Chain.Value(), payload.Address, payload.Identifier)
+		c.baseURL(o), payload.Chain.Value(), payload.Address, payload.Identifier)
 
-	return c.getEvents(ctx, payload, payload.Chain.IsTestNet(), url)
+	return c.getEvents(ctx, payload, o, url)
 }
 
 // ListEventsByCollection gets a list of events for a collection.
@@ -51,15 +58,16 @@ func (c *client) ListEventsByCollection(ctx context.Context, payload *openseamod
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointListEventsByCollection, payload
 
 	// GET /api/v2/events/collection/{collection_slug}
 	url := fmt.Sprintf("%s/api/v2/events/collection/%s",
-		openseaapiutils.GetBaseURL(o.testnets), payload.CollectionSlug)
+		c.baseURL(o), payload.CollectionSlug)
 
-	return c.getEvents(ctx, payload, o.testnets, url)
+	return c.getEvents(ctx, payload, o, url)
 }
 
-func (c *client) getEvents(ctx context.Context, payload Payloader, testnets bool, url string) (
+func (c *client) getEvents(ctx context.Context, payload Payloader, o *requestOptions, url string) (
 	*openseamodels.AssetEventResponse, error) {
 
 	if err := payload.Validate(); err != nil {
@@ -77,7 +85,7 @@ func (c *client) getEvents(ctx context.Context, payload Payloader, testnets bool
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
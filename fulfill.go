@@ -10,7 +10,6 @@ import (
 	"github.com/xTransact/errx/v3"
 
 	"github.com/xTransact/openseaapi/chain"
-	"github.com/xTransact/openseaapi/openseaapiutils"
 	"github.com/xTransact/openseaapi/openseaconsts"
 	"github.com/xTransact/openseaapi/openseamodels"
 )
@@ -19,15 +18,16 @@ import (
 // @Param orderHash: required: hash of the order to fulfill.
 // @Param ch (chain.Chain): required
 // @Param: fulfiller: required: fulfiller address
-func (c *client) FulfillListing(ctx context.Context, ch chain.Chain,
-	orderHash, fulfiller string) (resp *openseamodels.FulfillmentDataResponse, err error) {
+func (c *client) FulfillListing(ctx context.Context, ch chain.Chain, orderHash, fulfiller string,
+	opts ...RequestOptionFn) (resp *openseamodels.FulfillmentDataResponse, err error) {
 
 	return c.FulfillListingWithProtocolAddress(ctx, ch,
-		orderHash, fulfiller, openseaconsts.SeaportV16Address.String())
+		orderHash, fulfiller, openseaconsts.SeaportV16Address.String(), opts...)
 }
 
 func (c *client) FulfillListingWithProtocolAddress(ctx context.Context, ch chain.Chain,
-	orderHash, fulfiller, protocolAddress string) (resp *openseamodels.FulfillmentDataResponse, err error) {
+	orderHash, fulfiller, protocolAddress string,
+	opts ...RequestOptionFn) (resp *openseamodels.FulfillmentDataResponse, err error) {
 
 	if orderHash == "" || fulfiller == "" || ch < 0 {
 		return nil, errx.New("illegal arguments: nil")
@@ -49,8 +49,15 @@ func (c *client) FulfillListingWithProtocolAddress(ctx context.Context, ch chain
 		return nil, errx.Wrap(err, "marshal payload")
 	}
 
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointFulfillListing, ch, payload
+
 	// POST /api/v2/listings/fulfillment_data
-	url := fmt.Sprintf("%s/api/v2/listings/fulfillment_data", openseaapiutils.GetBaseURLByChain(ch))
+	url := fmt.Sprintf("%s/api/v2/listings/fulfillment_data", c.baseURL(o))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payloadData))
 	if err != nil {
@@ -59,7 +66,7 @@ func (c *client) FulfillListingWithProtocolAddress(ctx context.Context, ch chain
 
 	c.acceptJson(req)
 	c.contentTypeJson(req)
-	body, err := c.doRequest(req, ch.IsTestNet())
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -81,6 +88,7 @@ func (c *client) FulfillOffer(ctx context.Context, payload *openseamodels.Fulfil
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointFulfillOffer, payload
 
 	if err = payload.Validate(); err != nil {
 		return nil, err
@@ -92,7 +100,7 @@ func (c *client) FulfillOffer(ctx context.Context, payload *openseamodels.Fulfil
 	}
 
 	// POST /api/v2/offers/fulfillment_data
-	url := fmt.Sprintf("%s/api/v2/offers/fulfillment_data", openseaapiutils.GetBaseURL(o.testnets))
+	url := fmt.Sprintf("%s/api/v2/offers/fulfillment_data", c.baseURL(o))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payloadData))
 	if err != nil {
@@ -101,7 +109,7 @@ func (c *client) FulfillOffer(ctx context.Context, payload *openseamodels.Fulfil
 
 	c.acceptJson(req)
 	c.contentTypeJson(req)
-	body, err := c.doRequest(req, o.testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -0,0 +1,381 @@
+package openseaapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/xTransact/errx/v3"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+// PageFunc fetches the page of the given cursor, the first page for an empty cursor.
+// It returns the items of the page and the cursor of the next page, empty on the last page.
+type PageFunc[T any] func(ctx context.Context, cursor string) (items []T, next string, err error)
+
+// Iterator walks the items of a paginated endpoint, fetching the pages lazily:
+//
+//	it := IterNftsByCollection(ctx, cli, payload).MaxItems(1000)
+//	for it.Next() {
+//		nft := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		// the walk can be resumed later with ResumeFrom(it.Cursor())
+//	}
+//
+// The Iter functions start from the first page whatever the cursor of their payload, see ResumeFrom.
+// An Iterator is not safe for concurrent use.
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch PageFunc[T]
+
+	maxItems int
+	maxPages int
+
+	// cursor is the cursor of the current page, offset the number of its items already returned.
+	cursor string
+	offset int
+	page   []T
+	next   string
+	item   T
+
+	started bool
+	done    bool
+	items   int
+	pages   int
+	err     error
+}
+
+// NewIterator creates an iterator over the pages fetched by fetch.
+func NewIterator[T any](ctx context.Context, fetch PageFunc[T]) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch}
+}
+
+// MaxItems stops the iteration after n items, n <= 0 means no limit. It must be called before Next.
+func (it *Iterator[T]) MaxItems(n int) *Iterator[T] {
+	it.maxItems = n
+	return it
+}
+
+// MaxPages stops the iteration after n fetched pages, n <= 0 means no limit. It must be called before Next.
+func (it *Iterator[T]) MaxPages(n int) *Iterator[T] {
+	it.maxPages = n
+	return it
+}
+
+// iteratorCursor is the state of an iterator persisted by Cursor.
+type iteratorCursor struct {
+	Cursor string `json:"c,omitempty"`
+	Offset int    `json:"o,omitempty"`
+	Done   bool   `json:"d,omitempty"`
+}
+
+// ResumeFrom resumes the iteration from a token returned by Cursor, an empty token starts from the first page.
+// It must be called before Next, an invalid token is reported by Err.
+func (it *Iterator[T]) ResumeFrom(token string) *Iterator[T] {
+	if token == "" {
+		return it
+	}
+
+	var c iteratorCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil || c.Offset < 0 {
+		it.err = errx.Errorf("invalid iterator cursor %q", token)
+		return it
+	}
+
+	it.cursor, it.offset, it.done = c.Cursor, c.Offset, c.Done
+	return it
+}
+
+// Cursor returns an opaque token to resume the iteration after the last item returned by Item,
+// e.g. to persist the progress of a long walk and restart from it through ResumeFrom.
+func (it *Iterator[T]) Cursor() string {
+	c := iteratorCursor{Cursor: it.cursor, Offset: it.offset, Done: it.done && it.err == nil}
+	// 当前页已经读完，直接从下一页继续
+	if it.started && it.offset >= len(it.page) && it.next != "" {
+		c = iteratorCursor{Cursor: it.next}
+	}
+	if c == (iteratorCursor{}) {
+		return ""
+	}
+
+	data, _ := json.Marshal(&c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Next advances to the next item, fetching the next page if needed.
+// It returns false at the end of the iteration, when a limit is reached or on error, see Err.
+func (it *Iterator[T]) Next() bool {
+	if it.done || it.err != nil || (it.maxItems > 0 && it.items >= it.maxItems) {
+		return false
+	}
+
+	for !it.started || it.offset >= len(it.page) {
+		if it.started {
+			if it.next == "" {
+				it.done = true
+				return false
+			}
+			it.cursor, it.offset = it.next, 0
+		}
+		if it.maxPages > 0 && it.pages >= it.maxPages {
+			return false
+		}
+		if !it.fetchPage() {
+			return false
+		}
+	}
+
+	it.item = it.page[it.offset]
+	it.offset++
+	it.items++
+	return true
+}
+
+func (it *Iterator[T]) fetchPage() bool {
+	if err := it.ctx.Err(); err != nil {
+		it.err = errx.WithStack(err)
+		return false
+	}
+
+	page, next, err := it.fetch(it.ctx, it.cursor)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.pages++
+
+	// 从游标恢复时跳过已经返回过的条目
+	if !it.started && it.offset > len(page) {
+		it.offset = len(page)
+	}
+	it.started = true
+	it.page, it.next = page, next
+	return true
+}
+
+// Item returns the current item, valid after Next returned true.
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error which stopped the iteration, nil at the end of the pages or when a limit is reached.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// IterNftsByAccount iterates ListNftsByAccount, the payload is not modified.
+func IterNftsByAccount(ctx context.Context, s Servicer, ch chain.Chain,
+	payload *openseamodels.GetNftsByAccountPayload, opts ...RequestOptionFn) *Iterator[*openseamodels.Nft] {
+
+	return NewIterator(ctx, func(ctx context.Context, cursor string) ([]*openseamodels.Nft, string, error) {
+		p := *payload
+		p.GetNftsBasePayload = nftsBasePayloadAt(payload.GetNftsBasePayload, cursor)
+		resp, err := s.ListNftsByAccount(ctx, ch, &p, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return nftsOf(resp), resp.Next, nil
+	})
+}
+
+// IterNftsByContract iterates ListNftsByContract, the payload is not modified.
+func IterNftsByContract(ctx context.Context, s Servicer, ch chain.Chain,
+	payload *openseamodels.GetNftsByContractPayload, opts ...RequestOptionFn) *Iterator[*openseamodels.Nft] {
+
+	return NewIterator(ctx, func(ctx context.Context, cursor string) ([]*openseamodels.Nft, string, error) {
+		p := *payload
+		p.GetNftsBasePayload = nftsBasePayloadAt(payload.GetNftsBasePayload, cursor)
+		resp, err := s.ListNftsByContract(ctx, ch, &p, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return nftsOf(resp), resp.Next, nil
+	})
+}
+
+// IterNftsByCollection iterates ListNftsByCollection, the payload is not modified.
+func IterNftsByCollection(ctx context.Context, s Servicer,
+	payload *openseamodels.CollectionPayload, opts ...RequestOptionFn) *Iterator[*openseamodels.Nft] {
+
+	return NewIterator(ctx, func(ctx context.Context, cursor string) ([]*openseamodels.Nft, string, error) {
+		p := *payload
+		p.BaseQueryParams = baseQueryParamsAt(payload.BaseQueryParams, cursor)
+		resp, err := s.ListNftsByCollection(ctx, &p, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return nftsOf(resp), resp.Next, nil
+	})
+}
+
+// IterCollections iterates ListCollections, the payload is not modified.
+func IterCollections(ctx context.Context, s Servicer,
+	payload *openseamodels.ListCollectionsPayload, opts ...RequestOptionFn) *Iterator[*openseamodels.Collection] {
+
+	return NewIterator(ctx, func(ctx context.Context, cursor string) ([]*openseamodels.Collection, string, error) {
+		p := *payload
+		p.Next = cursor
+		resp, err := s.ListCollections(ctx, &p, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Collections, resp.Next, nil
+	})
+}
+
+// IterEventsByAccount iterates ListEventsByAccount, the payload is not modified.
+func IterEventsByAccount(ctx context.Context, s Servicer,
+	payload *openseamodels.GetEventsByAccountPayload, opts ...RequestOptionFn) *Iterator[openseamodels.AssetEvent] {
+
+	return NewIterator(ctx, func(ctx context.Context, cursor string) ([]openseamodels.AssetEvent, string, error) {
+		p := *payload
+		p.GetEventsQueryParams = eventsQueryParamsAt(payload.GetEventsQueryParams, cursor)
+		resp, err := s.ListEventsByAccount(ctx, &p, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.AssetEvent, resp.Next, nil
+	})
+}
+
+// IterEventsByNft iterates ListEventsByNft, the payload is not modified.
+func IterEventsByNft(ctx context.Context, s Servicer,
+	payload *openseamodels.GetEventsByNftPayload, opts ...RequestOptionFn) *Iterator[openseamodels.AssetEvent] {
+
+	return NewIterator(ctx, func(ctx context.Context, cursor string) ([]openseamodels.AssetEvent, string, error) {
+		p := *payload
+		p.GetEventsQueryParams = eventsQueryParamsAt(payload.GetEventsQueryParams, cursor)
+		resp, err := s.ListEventsByNft(ctx, &p, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.AssetEvent, resp.Next, nil
+	})
+}
+
+// IterEventsByCollection iterates ListEventsByCollection, the payload is not modified.
+func IterEventsByCollection(ctx context.Context, s Servicer,
+	payload *openseamodels.GetEventsByCollectionPayload, opts ...RequestOptionFn) *Iterator[openseamodels.AssetEvent] {
+
+	return NewIterator(ctx, func(ctx context.Context, cursor string) ([]openseamodels.AssetEvent, string, error) {
+		p := *payload
+		p.GetEventsQueryParams = eventsQueryParamsAt(payload.GetEventsQueryParams, cursor)
+		resp, err := s.ListEventsByCollection(ctx, &p, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.AssetEvent, resp.Next, nil
+	})
+}
+
+// IterAllListingsByCollection iterates GetAllListingsByCollection, the payload is not modified.
+func IterAllListingsByCollection(ctx context.Context, s Servicer,
+	payload *openseamodels.GetAllListingsByCollectionPayload,
+	opts ...RequestOptionFn) *Iterator[*openseamodels.CollectionListing] {
+
+	return NewIterator(ctx, func(ctx context.Context, cursor string) ([]*openseamodels.CollectionListing, string, error) {
+		p := *payload
+		p.Next = cursor
+		resp, err := s.GetAllListingsByCollection(ctx, &p, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Listings, resp.Next, nil
+	})
+}
+
+// IterAllCollectionOffers iterates GetAllCollectionOffers, the payload is not modified.
+func IterAllCollectionOffers(ctx context.Context, s Servicer,
+	payload *openseamodels.CollectionPayload, opts ...RequestOptionFn) *Iterator[openseamodels.OfferResponse] {
+
+	return NewIterator(ctx, func(ctx context.Context, cursor string) ([]openseamodels.OfferResponse, string, error) {
+		p := *payload
+		p.BaseQueryParams = baseQueryParamsAt(payload.BaseQueryParams, cursor)
+		resp, err := s.GetAllCollectionOffers(ctx, &p, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Offers, resp.Next, nil
+	})
+}
+
+// IterListings iterates GetListings, the payload is not modified.
+func IterListings(ctx context.Context, s Servicer, ch chain.Chain,
+	payload *openseamodels.OrderPayload, opts ...RequestOptionFn) *Iterator[*openseamodels.OrderResponse] {
+
+	return NewIterator(ctx, func(ctx context.Context, cursor string) ([]*openseamodels.OrderResponse, string, error) {
+		resp, err := s.GetListings(ctx, ch, orderPayloadAt(payload, cursor), opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Orders, resp.Next, nil
+	})
+}
+
+// IterIndividualOffers iterates GetIndividualOffers, the payload is not modified.
+func IterIndividualOffers(ctx context.Context, s Servicer, ch chain.Chain,
+	payload *openseamodels.OrderPayload, opts ...RequestOptionFn) *Iterator[*openseamodels.OrderResponse] {
+
+	return NewIterator(ctx, func(ctx context.Context, cursor string) ([]*openseamodels.OrderResponse, string, error) {
+		resp, err := s.GetIndividualOffers(ctx, ch, orderPayloadAt(payload, cursor), opts...)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Orders, resp.Next, nil
+	})
+}
+
+func nftsOf(resp *openseamodels.NftsResponse) []*openseamodels.Nft {
+	if resp.Nfts == nil {
+		return nil
+	}
+	return resp.Nfts.Nfts
+}
+
+// 以下函数复制 payload 中携带游标的部分，避免修改调用方的 payload
+
+func baseQueryParamsAt(b *openseamodels.BaseQueryParams, cursor string) *openseamodels.BaseQueryParams {
+	cp := new(openseamodels.BaseQueryParams)
+	if b != nil {
+		*cp = *b
+	}
+	cp.Next = cursor
+	return cp
+}
+
+func nftsBasePayloadAt(b *openseamodels.GetNftsBasePayload, cursor string) *openseamodels.GetNftsBasePayload {
+	cp := new(openseamodels.GetNftsBasePayload)
+	if b != nil {
+		*cp = *b
+	}
+	cp.BaseQueryParams = baseQueryParamsAt(cp.BaseQueryParams, cursor)
+	return cp
+}
+
+func eventsQueryParamsAt(q *openseamodels.GetEventsQueryParams, cursor string) *openseamodels.GetEventsQueryParams {
+	cp := new(openseamodels.GetEventsQueryParams)
+	if q != nil {
+		*cp = *q
+	}
+	cp.Next = nil
+	if cursor != "" {
+		cp.Next = &cursor
+	}
+	return cp
+}
+
+func orderPayloadAt(p *openseamodels.OrderPayload, cursor string) *openseamodels.OrderPayload {
+	cp := *p
+	cp.Cursor = nil
+	if cursor != "" {
+		cp.Cursor = &cursor
+	}
+	return &cp
+}
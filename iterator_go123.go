@@ -0,0 +1,28 @@
+//go:build go1.23
+
+package openseaapi
+
+import "iter"
+
+// All returns the items of the iterator as a range-over-func sequence:
+//
+//	for nft, err := range IterNftsByCollection(ctx, cli, payload).All() {
+//		if err != nil {
+//			return err
+//		}
+//	}
+//
+// The error stopping the iteration, if any, is yielded last with the zero item.
+func (it *Iterator[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next() {
+			if !yield(it.Item(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+//go:build go1.23
+
+package openseaapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIteratorAll(t *testing.T) {
+	var fetched []string
+	var items []string
+	for item, err := range NewIterator(context.Background(), fakePages(&fetched)).MaxItems(5).All() {
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, items)
+
+	var errs []error
+	for _, err := range NewIterator(context.Background(), fakePages(&fetched)).ResumeFrom("!").All() {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	require.Error(t, errs[0])
+}
@@ -0,0 +1,133 @@
+package openseaapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+// fakePages serves the pages ["0","1","2"], ["3","4","5"], ["6"] through the cursors "", "p1" and "p2".
+func fakePages(fetched *[]string) PageFunc[string] {
+	pages := map[string][]string{"": {"0", "1", "2"}, "p1": {"3", "4", "5"}, "p2": {"6"}}
+	next := map[string]string{"": "p1", "p1": "p2"}
+	return func(ctx context.Context, cursor string) ([]string, string, error) {
+		*fetched = append(*fetched, cursor)
+		page, ok := pages[cursor]
+		if !ok {
+			return nil, "", errors.New("unknown cursor")
+		}
+		return page, next[cursor], nil
+	}
+}
+
+func collect(it *Iterator[string]) []string {
+	var items []string
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items
+}
+
+func TestIterator(t *testing.T) {
+	var fetched []string
+	it := NewIterator(context.Background(), fakePages(&fetched))
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6"}, collect(it))
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"", "p1", "p2"}, fetched)
+	assert.False(t, it.Next())
+
+	// a finished walk resumes to nothing
+	fetched = nil
+	assert.Empty(t, collect(NewIterator(context.Background(), fakePages(&fetched)).ResumeFrom(it.Cursor())))
+	assert.Empty(t, fetched)
+}
+
+func TestIteratorLimits(t *testing.T) {
+	var fetched []string
+	it := NewIterator(context.Background(), fakePages(&fetched)).MaxItems(4)
+	assert.Equal(t, []string{"0", "1", "2", "3"}, collect(it))
+	require.NoError(t, it.Err())
+
+	fetched = nil
+	it = NewIterator(context.Background(), fakePages(&fetched)).MaxPages(2)
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5"}, collect(it))
+	assert.Equal(t, []string{"", "p1"}, fetched)
+}
+
+func TestIteratorResume(t *testing.T) {
+	var fetched []string
+	it := NewIterator(context.Background(), fakePages(&fetched)).MaxItems(4)
+	collect(it)
+
+	// the walk resumes right after the last returned item, in the middle of a page
+	rest := NewIterator(context.Background(), fakePages(&fetched)).ResumeFrom(it.Cursor())
+	assert.Equal(t, []string{"4", "5", "6"}, collect(rest))
+
+	// at the end of a page, the walk resumes from the next page
+	fetched = nil
+	it = NewIterator(context.Background(), fakePages(&fetched)).MaxItems(3)
+	collect(it)
+	rest = NewIterator(context.Background(), fakePages(&fetched)).ResumeFrom(it.Cursor())
+	assert.Equal(t, []string{"3", "4", "5", "6"}, collect(rest))
+	assert.Equal(t, []string{"", "p1", "p2"}, fetched)
+
+	it = NewIterator(context.Background(), fakePages(&fetched)).ResumeFrom("not a cursor")
+	assert.False(t, it.Next())
+	require.Error(t, it.Err())
+}
+
+func TestIteratorError(t *testing.T) {
+	fetch := func(ctx context.Context, cursor string) ([]string, string, error) {
+		if cursor == "" {
+			return []string{"0"}, "p1", nil
+		}
+		return nil, "", ErrServer
+	}
+
+	it := NewIterator(context.Background(), fetch)
+	assert.Equal(t, []string{"0"}, collect(it))
+	require.ErrorIs(t, it.Err(), ErrServer)
+
+	// the failed page is fetched again on resume
+	var cursors []string
+	retry := NewIterator(context.Background(), func(ctx context.Context, cursor string) ([]string, string, error) {
+		cursors = append(cursors, cursor)
+		return []string{"1"}, "", nil
+	}).ResumeFrom(it.Cursor())
+	assert.Equal(t, []string{"1"}, collect(retry))
+	assert.Equal(t, []string{"p1"}, cursors)
+}
+
+func TestIterNftsByCollection(t *testing.T) {
+	var cursors []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("next")
+		cursors = append(cursors, cursor)
+		n, _ := strconv.Atoi(cursor)
+		next := ""
+		if n < 2 {
+			next = strconv.Itoa(n + 1)
+		}
+		_, _ = w.Write([]byte(`{"nfts":[{"identifier":"` + strconv.Itoa(n) + `"}],"next":"` + next + `"}`))
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""))
+	payload := &openseamodels.CollectionPayload{CollectionSlug: "azuki"}
+
+	var ids []string
+	it := IterNftsByCollection(context.Background(), cli, payload)
+	for it.Next() {
+		ids = append(ids, it.Item().Identifier)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"0", "1", "2"}, ids)
+	assert.Equal(t, []string{"", "1", "2"}, cursors)
+	assert.Nil(t, payload.BaseQueryParams)
+}
@@ -0,0 +1,178 @@
+package openseaapi
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// KeyStrategy selects the key of a KeyPool used by the next request.
+type KeyStrategy int
+
+const (
+	// KeyStrategyRoundRobin uses the keys in turn.
+	KeyStrategyRoundRobin KeyStrategy = iota
+	// KeyStrategyLeastRecentlyThrottled uses the key throttled the longest time ago, never throttled keys first.
+	KeyStrategyLeastRecentlyThrottled
+)
+
+const (
+	// defaultThrottledBench is how long a key answered with 429 is benched when OpenSea sent no Retry-After.
+	defaultThrottledBench = time.Minute
+	// unauthorizedBench is how long a key answered with 401 is benched, e.g. a revoked key.
+	unauthorizedBench = 10 * time.Minute
+)
+
+// KeyStats are the usage counters of a key of a KeyPool.
+type KeyStats struct {
+	Key string
+	// Requests is the number of http attempts made with the key.
+	Requests uint64
+	// Throttled is the number of attempts answered with 429 Too Many Requests.
+	Throttled uint64
+	// Unauthorized is the number of attempts answered with 401 Unauthorized.
+	Unauthorized uint64
+	// LastThrottled is the time of the last 429 or 401, zero if none.
+	LastThrottled time.Time
+	// BenchedUntil is set while the key is benched after a 429 or 401.
+	BenchedUntil time.Time
+}
+
+// KeyPool spreads the requests of one or several clients across several OpenSea API keys, see WithKeyPool.
+// A key answered with 429 or 401 is benched for a while: it is only used again when every other key is benched too.
+type KeyPool struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	strategy KeyStrategy
+	keys     []*KeyStats
+	next     int
+}
+
+// NewKeyPool creates a pool of keys used according to the strategy.
+func NewKeyPool(strategy KeyStrategy, keys ...string) *KeyPool {
+	p := &KeyPool{now: time.Now, strategy: strategy}
+	p.SetKeys(keys...)
+	return p
+}
+
+// SetKeys replaces the keys of the pool at runtime, e.g. after a key rotation.
+// The counters of the keys kept in the pool are preserved.
+func (p *KeyPool) SetKeys(keys ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	known := make(map[string]*KeyStats, len(p.keys))
+	for _, k := range p.keys {
+		known[k.Key] = k
+	}
+
+	p.keys = make([]*KeyStats, 0, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if k, ok := known[key]; ok {
+			p.keys = append(p.keys, k)
+			delete(known, key)
+			continue
+		}
+		p.keys = append(p.keys, &KeyStats{Key: key})
+	}
+	p.next = 0
+}
+
+// Stats returns a snapshot of the counters of the keys of the pool.
+func (p *KeyPool) Stats() []KeyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]KeyStats, len(p.keys))
+	for i, k := range p.keys {
+		stats[i] = *k
+	}
+	return stats
+}
+
+// pick returns the key of the next request, false when the pool is empty.
+func (p *KeyPool) pick() (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.keys) == 0 {
+		return "", false
+	}
+
+	now := p.now()
+	var picked *KeyStats
+	switch p.strategy {
+	case KeyStrategyLeastRecentlyThrottled:
+		for _, k := range p.keys {
+			if k.BenchedUntil.After(now) {
+				continue
+			}
+			if picked == nil || k.LastThrottled.Before(picked.LastThrottled) ||
+				(k.LastThrottled.Equal(picked.LastThrottled) && k.Requests < picked.Requests) {
+				picked = k
+			}
+		}
+	default:
+		for i := range p.keys {
+			k := p.keys[(p.next+i)%len(p.keys)]
+			if !k.BenchedUntil.After(now) {
+				picked = k
+				p.next = (p.next + i + 1) % len(p.keys)
+				break
+			}
+		}
+	}
+
+	// 所有 key 都被暂停时，使用最早恢复的那个
+	if picked == nil {
+		for _, k := range p.keys {
+			if picked == nil || k.BenchedUntil.Before(picked.BenchedUntil) {
+				picked = k
+			}
+		}
+	}
+
+	picked.Requests++
+	return picked.Key, true
+}
+
+// observe benches the key when OpenSea answered 429 or 401.
+func (p *KeyPool) observe(key string, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	var bench time.Duration
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		bench = defaultThrottledBench
+		if wait, ok := parseRetryAfter(resp); ok {
+			bench = wait
+		}
+	case http.StatusUnauthorized:
+		bench = unauthorizedBench
+	default:
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, k := range p.keys {
+		if k.Key != key {
+			continue
+		}
+		now := p.now()
+		if resp.StatusCode == http.StatusTooManyRequests {
+			k.Throttled++
+		} else {
+			k.Unauthorized++
+		}
+		k.LastThrottled = now
+		k.BenchedUntil = now.Add(bench)
+		return
+	}
+}
@@ -0,0 +1,123 @@
+package openseaapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithApiKeys(t *testing.T) {
+	var mu sync.Mutex
+	var used []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		used = append(used, r.Header.Get("x-api-key"))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithApiKey("ignored"), WithApiKeys("a", "b", "c"))
+	for i := 0; i < 4; i++ {
+		_, err := cli.GetCollection(context.Background(), "azuki")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a"}, used)
+}
+
+func TestKeyPoolBenchesThrottledKeys(t *testing.T) {
+	var used []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("x-api-key")
+		used = append(used, key)
+		switch key {
+		case "throttled":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "revoked":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	})
+
+	pool := NewKeyPool(KeyStrategyRoundRobin, "throttled", "ok")
+	cli := NewClient(
+		WithBaseURL(srv.URL, ""),
+		WithKeyPool(pool),
+		WithRetryPolicy(&BackoffRetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+	)
+
+	ctx := context.Background()
+	// the retry of the throttled attempt uses the other key
+	_, err := cli.GetCollection(ctx, "azuki")
+	require.NoError(t, err)
+	_, err = cli.GetCollection(ctx, "azuki")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"throttled", "ok", "ok"}, used)
+
+	stats := pool.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, KeyStats{Key: "throttled", Requests: 1, Throttled: 1,
+		LastThrottled: stats[0].LastThrottled, BenchedUntil: stats[0].BenchedUntil}, stats[0])
+	assert.WithinDuration(t, time.Now().Add(defaultThrottledBench), stats[0].BenchedUntil, time.Second)
+	assert.EqualValues(t, 2, stats[1].Requests)
+
+	// hot swap: the counters of the kept keys survive
+	pool.SetKeys("ok", "revoked")
+	used = nil
+	_, err = cli.GetCollection(ctx, "azuki")
+	require.NoError(t, err)
+	_, err = cli.GetCollection(ctx, "azuki")
+	require.ErrorIs(t, err, ErrUnauthorized)
+	_, err = cli.GetCollection(ctx, "azuki")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ok", "revoked", "ok"}, used)
+
+	stats = pool.Stats()
+	assert.EqualValues(t, 4, stats[0].Requests)
+	assert.EqualValues(t, 1, stats[1].Unauthorized)
+}
+
+func TestKeyPoolLeastRecentlyThrottled(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	pool := NewKeyPool(KeyStrategyLeastRecentlyThrottled, "a", "b", "c")
+	pool.now = func() time.Time { return now }
+
+	throttle := func(key string, retryAfter string) {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		resp.Header.Set("Retry-After", retryAfter)
+		pool.observe(key, resp)
+	}
+
+	throttle("a", "10")
+	now = now.Add(time.Second)
+	throttle("b", "10")
+
+	// c was never throttled
+	for i := 0; i < 2; i++ {
+		key, ok := pool.pick()
+		require.True(t, ok)
+		assert.Equal(t, "c", key)
+	}
+
+	now = now.Add(20 * time.Second)
+	throttle("c", "10")
+	key, _ := pool.pick()
+	assert.Equal(t, "a", key)
+
+	// every key is benched: the first to recover is used
+	throttle("a", "30")
+	throttle("b", "5")
+	key, _ = pool.pick()
+	assert.Equal(t, "b", key)
+}
+
+func TestKeyPoolEmpty(t *testing.T) {
+	pool := NewKeyPool(KeyStrategyRoundRobin, "")
+	_, ok := pool.pick()
+	assert.False(t, ok)
+}
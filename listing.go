@@ -10,7 +10,6 @@ import (
 	"github.com/xTransact/errx/v3"
 
 	"github.com/xTransact/openseaapi/chain"
-	"github.com/xTransact/openseaapi/openseaapiutils"
 	"github.com/xTransact/openseaapi/openseaconsts"
 	"github.com/xTransact/openseaapi/openseamodels"
 )
@@ -18,12 +17,19 @@ import (
 // GetListings gets the complete set of active, valid listings.
 // @Param ch (chain.Chain): The blockchain on which to filter the results.
 // DOC: https://docs.opensea.io/reference/get_listings
-func (c *client) GetListings(ctx context.Context, ch chain.Chain, payload *openseamodels.OrderPayload) (
-	resp *openseamodels.OrdersResponse, err error) {
+func (c *client) GetListings(ctx context.Context, ch chain.Chain, payload *openseamodels.OrderPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.OrdersResponse, err error) {
+
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointGetListings, ch, payload
 
 	// POST /api/v2/orders/{chain}/{protocol}/listings
 	url := fmt.Sprintf("%s/api/v2/orders/%s/%s/listings",
-		openseaapiutils.GetBaseURLByChain(ch), ch.Value(), openseaconsts.ProtocolName)
+		c.baseURL(o), ch.Value(), openseaconsts.ProtocolName)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -42,7 +48,7 @@ func (c *client) GetListings(ctx context.Context, ch chain.Chain, payload *opens
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, ch.IsTestNet())
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -67,9 +73,10 @@ func (c *client) GetAllListingsByCollection(ctx context.Context, payload *opense
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointGetAllListingsByCollection, payload
 
 	// GET /api/v2/listings/collection/{collection_slug}/all
-	url := fmt.Sprintf("%s/api/v2/listings/collection/%s/all", openseaapiutils.GetBaseURL(o.testnets), payload.CollectionSlug)
+	url := fmt.Sprintf("%s/api/v2/listings/collection/%s/all", c.baseURL(o), payload.CollectionSlug)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -86,7 +93,7 @@ func (c *client) GetAllListingsByCollection(ctx context.Context, payload *opense
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, o.testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -101,9 +108,12 @@ func (c *client) GetAllListingsByCollection(ctx context.Context, payload *opense
 
 // CreateListing lists a single NFT (ERC721 or ERC1155) for sale on the OpenSea marketplace.
 // DOC: https://docs.opensea.io/reference/post_listing
-func (c *client) CreateListing(ctx context.Context, ch chain.Chain,
-	payload *openseamodels.CreateOrderPayload) (resp *openseamodels.CreateListingResponse, err error) {
+func (c *client) CreateListing(ctx context.Context, ch chain.Chain, payload *openseamodels.CreateOrderPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.CreateListingResponse, err error) {
 
+	if err = c.signOrder(ctx, ch, payload); err != nil {
+		return nil, errx.Wrap(err, "sign order")
+	}
 	if err = payload.Validate(); err != nil {
 		return nil, errx.Wrap(err, "invalid payload")
 	}
@@ -113,8 +123,15 @@ func (c *client) CreateListing(ctx context.Context, ch chain.Chain,
 		return nil, errx.Wrap(err, "marshal payload")
 	}
 
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointCreateListing, ch, payload
+
 	// POST /api/v2/orders/{chain}/{protocol}/listings
-	url := fmt.Sprintf("%s/api/v2/orders/%s/%s/listings", openseaapiutils.GetBaseURLByChain(ch), ch.Value(), openseaconsts.ProtocolName)
+	url := fmt.Sprintf("%s/api/v2/orders/%s/%s/listings", c.baseURL(o), ch.Value(), openseaconsts.ProtocolName)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payloadData))
 	if err != nil {
@@ -123,7 +140,7 @@ func (c *client) CreateListing(ctx context.Context, ch chain.Chain,
 
 	c.acceptJson(req)
 	c.contentTypeJson(req)
-	body, err := c.doRequest(req, ch.IsTestNet())
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -135,3 +152,112 @@ func (c *client) CreateListing(ctx context.Context, ch chain.Chain,
 
 	return resp, nil
 }
+
+// GetBestListing gets the cheapest active, valid listing of a single NFT.
+// When OpenSea answers that it does not serve the endpoint, e.g. on a chain where it is unsupported, or with
+// ScanOrderPages, the listing is computed from the pages of GetAllListingsByCollection instead, see ScanOrderPages.
+// @Param collection_slug: required: Unique string to identify a collection on OpenSea.
+// @Param identifier: required: The NFT token id.
+// @Param include_private_listings: If true, private listings will be included in the response. Default: false
+// DOC: https://docs.opensea.io/reference/get_best_listing_on_nft_v2
+func (c *client) GetBestListing(ctx context.Context, payload *openseamodels.GetBestListingPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.CollectionListing, err error) {
+
+	if err = payload.Validate(); err != nil {
+		return nil, errx.Wrap(err, "invalid payload")
+	}
+
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.endpoint, o.payload = EndpointGetBestListing, payload
+	if o.scanOrderPages {
+		return bestListingFromPages(ctx, c, payload, opts...)
+	}
+
+	// GET /api/v2/listings/collection/{collection_slug}/nfts/{identifier}/best
+	url := fmt.Sprintf("%s/api/v2/listings/collection/%s/nfts/%s/best",
+		c.baseURL(o), payload.CollectionSlug, payload.Identifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+
+	qs := payload.ToQuery()
+	if len(qs) > 0 {
+		req.URL.RawQuery = qs.Encode()
+	}
+
+	c.acceptJson(req)
+	body, err := c.doRequest(req, o)
+	if err != nil {
+		if isBestOrderUnsupported(err) {
+			return bestListingFromPages(ctx, c, payload, opts...)
+		}
+		return nil, errx.WithStack(err)
+	}
+
+	resp = new(openseamodels.CollectionListing)
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errx.Wrap(err, "unmarshal response body")
+	}
+
+	return resp, nil
+}
+
+// GetBestListings gets the cheapest active, valid listings of a collection, one per NFT and sorted by price.
+// When OpenSea answers that it does not serve the endpoint, e.g. on a chain where it is unsupported, or with
+// ScanOrderPages, the listings are computed from the pages of GetAllListingsByCollection instead
+// and returned in a single page without next cursor, see ScanOrderPages.
+// @Param collection_slug: required: Unique string to identify a collection on OpenSea.
+// @Param include_private_listings: If true, private listings will be included in the response. Default: false
+// @Param limit: The number of listings to return. Must be between 1 and 100. Default: 100
+// @Param next: The cursor for the next page of results. This is returned from a previous request.
+// DOC: https://docs.opensea.io/reference/get_best_listings_on_collection_v2
+func (c *client) GetBestListings(ctx context.Context, payload *openseamodels.GetBestListingsPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.ListingsByCollectionResponse, err error) {
+
+	if err = payload.Validate(); err != nil {
+		return nil, errx.Wrap(err, "invalid payload")
+	}
+
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.endpoint, o.payload = EndpointGetBestListings, payload
+	if o.scanOrderPages {
+		return bestListingsFromPages(ctx, c, payload, opts...)
+	}
+
+	// GET /api/v2/listings/collection/{collection_slug}/best
+	url := fmt.Sprintf("%s/api/v2/listings/collection/%s/best", c.baseURL(o), payload.CollectionSlug)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+
+	qs := payload.ToQuery()
+	if len(qs) > 0 {
+		req.URL.RawQuery = qs.Encode()
+	}
+
+	c.acceptJson(req)
+	body, err := c.doRequest(req, o)
+	if err != nil {
+		if isBestOrderUnsupported(err) {
+			return bestListingsFromPages(ctx, c, payload, opts...)
+		}
+		return nil, errx.WithStack(err)
+	}
+
+	resp = new(openseamodels.ListingsByCollectionResponse)
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errx.Wrap(err, "unmarshal response body")
+	}
+
+	return resp, nil
+}
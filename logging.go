@@ -0,0 +1,138 @@
+package openseaapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	redacted = "[REDACTED]"
+	// maxDumpedBodySize caps the size of a body dumped by verbose logging.
+	maxDumpedBodySize = 8 << 10
+)
+
+// redactedHeaders are never logged in clear.
+var redactedHeaders = []string{"x-api-key", "authorization", "cookie"}
+
+// redactedFields are JSON fields whose values are never logged in clear,
+// e.g. the signature of a CreateOrderPayload.
+var redactedFields = []string{"signature", "offerer_signature"}
+
+// verboseLogging logs every call going through the doer, see EnableVerbose and EnableBodyDump.
+func (c *client) verboseLogging(next Doer) Doer {
+	return DoerFunc(func(req *Request) (*Response, error) {
+		ctx := req.Context()
+		r := req.HTTPRequest
+		logger := c.logger().With(
+			"endpoint", req.Endpoint,
+			"method", r.Method,
+			"url", r.URL.Scheme+"://"+r.URL.Host+r.URL.Path,
+			"query", r.URL.RawQuery,
+			"testnets", req.Testnets,
+		)
+
+		if c.config.dumpBody {
+			logger.InfoContext(ctx, "[OpenSea API] Sending request",
+				"header", redactHeader(r.Header),
+				"body", dumpRequestBody(r))
+		}
+
+		start := time.Now()
+		resp, err := next.Do(req)
+		attrs := []any{"latency", time.Since(start)}
+		if resp != nil {
+			attrs = append(attrs, "status", resp.StatusCode, "size", len(resp.Body))
+			if c.config.dumpBody {
+				attrs = append(attrs, "body", redactBody(resp.Body))
+			}
+		}
+
+		if err != nil {
+			logger.WarnContext(ctx, "[OpenSea API] Request failed", append(attrs, "err", err)...)
+		} else {
+			logger.InfoContext(ctx, "[OpenSea API] Request done", attrs...)
+		}
+
+		return resp, err
+	})
+}
+
+// redactHeader returns a copy of the header with its credentials redacted.
+func redactHeader(h http.Header) http.Header {
+	cp := h.Clone()
+	for _, key := range redactedHeaders {
+		if cp.Get(key) != "" {
+			cp.Set(key, redacted)
+		}
+	}
+	return cp
+}
+
+// dumpRequestBody reads a copy of the request body through GetBody, leaving the request untouched.
+func dumpRequestBody(r *http.Request) string {
+	if r.GetBody == nil || r.Body == nil || r.Body == http.NoBody {
+		return ""
+	}
+
+	body, err := r.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+
+	return redactBody(data)
+}
+
+// redactBody redacts the sensitive fields of a JSON body and truncates it to maxDumpedBodySize.
+func redactBody(data []byte) string {
+	// UseNumber 保留大整数（wei 金额、token id、salt）的原值，不转成 float64
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err == nil && !dec.More() {
+		if redactedData, err := json.Marshal(redactValue(v)); err == nil {
+			data = redactedData
+		}
+	}
+
+	if len(data) > maxDumpedBodySize {
+		return string(data[:maxDumpedBodySize]) + "...(truncated)"
+	}
+	return string(data)
+}
+
+func redactValue(v any) any {
+	switch vv := v.(type) {
+	case map[string]any:
+		for key, value := range vv {
+			if isRedactedField(key) {
+				vv[key] = redacted
+				continue
+			}
+			vv[key] = redactValue(value)
+		}
+	case []any:
+		for i, value := range vv {
+			vv[i] = redactValue(value)
+		}
+	}
+	return v
+}
+
+func isRedactedField(key string) bool {
+	for _, field := range redactedFields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+	return false
+}
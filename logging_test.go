@@ -0,0 +1,91 @@
+package openseaapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseaenums"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+func TestVerboseLogging(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"order":{"order_hash":"0x01","protocol_data":{"signature":"0xresponse-secret"}}}`))
+	})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithApiKey("api-secret"), WithLogger(logger), EnableBodyDump())
+
+	_, err := cli.CreateListing(context.Background(), chain.Ethereum, testCreateOrderPayload())
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.NotContains(t, out, "api-secret")
+	assert.NotContains(t, out, "0xrequest-secret")
+	assert.NotContains(t, out, "0xresponse-secret")
+	assert.Contains(t, out, redacted)
+
+	var done map[string]any
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	require.NoError(t, json.Unmarshal(lines[1], &done))
+	assert.Equal(t, EndpointCreateListing, done["endpoint"])
+	assert.Equal(t, http.MethodPost, done["method"])
+	assert.EqualValues(t, http.StatusOK, done["status"])
+	assert.NotZero(t, done["size"])
+}
+
+func TestVerboseLoggingDisabled(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	var buf bytes.Buffer
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	_, err := cli.GetCollection(context.Background(), "azuki")
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func testCreateOrderPayload() *openseamodels.CreateOrderPayload {
+	return &openseamodels.CreateOrderPayload{
+		Parameters: &openseamodels.Parameters{
+			Offerer: "0x69493301a10A06679a6771D33E8CDd3a5fdA4dB4",
+			Offer: []*openseamodels.Offer{{
+				BaseOfferAndConsideration: &openseamodels.BaseOfferAndConsideration{
+					ItemType:             openseaenums.ItemTypeERC721,
+					IdentifierOrCriteria: "1",
+					StartAmount:          "1",
+					EndAmount:            "1",
+				},
+			}},
+			StartTime:  "1700000000",
+			EndTime:    "1800000000",
+			Zone:       "0x0000000000000000000000000000000000000000",
+			ZoneHash:   "0x0000000000000000000000000000000000000000000000000000000000000000",
+			Salt:       "1",
+			ConduitKey: "0x0000007b02230091a7ed01230072f7006a004d60a8d4e71d599b8104250f0000",
+			Counter:    "0",
+		},
+		Signature:       "0xrequest-secret",
+		ProtocolAddress: "0x0000000000000068f116a894984e2db1123eb395",
+	}
+}
+
+func TestRedactBodyKeepsNumbers(t *testing.T) {
+	out := redactBody([]byte(`{"salt":123456789012345678901234567890,"amount":1000000000000000001,"signature":"0x01"}`))
+	assert.JSONEq(t, `{"salt":123456789012345678901234567890,"amount":1000000000000000001,"signature":"[REDACTED]"}`, out)
+	assert.Contains(t, out, "1000000000000000001")
+
+	// 不是 JSON 时原样输出
+	assert.Equal(t, `{"a":1} trailing`, redactBody([]byte(`{"a":1} trailing`)))
+}
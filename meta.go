@@ -0,0 +1,74 @@
+package openseaapi
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// requestIDHeaders are the headers identifying a request to the OpenSea support, by order of preference.
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "Cf-Ray"}
+
+// ResponseMeta is the metadata of the response of a call, filled by UseResponseMeta.
+type ResponseMeta struct {
+	// StatusCode is the HTTP status code of the response, zero when no response was received.
+	StatusCode int
+	// Header is the header of the response.
+	Header http.Header
+	// RateLimit are the rate limit headers of the response.
+	RateLimit RateLimitHeaders
+	// RequestID identifies the request for the OpenSea support, e.g. X-Request-Id or Cf-Ray.
+	RequestID string
+	// Attempts is the number of http attempts made for the call,
+	// zero when the call was answered without sending a request, e.g. from the cache.
+	Attempts int
+	// Retries is the number of attempts retried by the retry policy.
+	Retries int
+	// Body is the raw body of the response, e.g. to read the fields not mapped by openseamodels.
+	Body []byte
+}
+
+// RateLimitHeaders are the rate limit headers sent by OpenSea.
+type RateLimitHeaders struct {
+	// Limit is X-RateLimit-Limit, -1 when missing.
+	Limit int
+	// Remaining is X-RateLimit-Remaining, -1 when missing.
+	Remaining int
+	// Reset is X-RateLimit-Reset, zero when missing.
+	Reset time.Time
+	// RetryAfter is Retry-After, zero when missing.
+	RetryAfter time.Duration
+}
+
+// fill records the metadata of a call once the doer answered it.
+func (m *ResponseMeta) fill(req *Request, resp *Response) {
+	*m = ResponseMeta{
+		RateLimit: RateLimitHeaders{Limit: -1, Remaining: -1},
+		Attempts:  req.attempts,
+		Retries:   max(req.attempts-1, 0),
+	}
+	if resp == nil {
+		return
+	}
+
+	m.StatusCode, m.Header, m.Body = resp.StatusCode, resp.Header, resp.Body
+	for _, key := range requestIDHeaders {
+		if v := resp.Header.Get(key); v != "" {
+			m.RequestID = v
+			break
+		}
+	}
+
+	if v, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil {
+		m.RateLimit.Limit = v
+	}
+	if v, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		m.RateLimit.Remaining = v
+	}
+	if reset, ok := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), time.Now()); ok {
+		m.RateLimit.Reset = reset
+	}
+	if wait, ok := parseRetryAfter(&http.Response{Header: resp.Header}); ok {
+		m.RateLimit.RetryAfter = wait
+	}
+}
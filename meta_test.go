@@ -0,0 +1,65 @@
+package openseaapi
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseResponseMeta(t *testing.T) {
+	var attempts atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Header().Set("X-RateLimit-Limit", "4")
+		w.Header().Set("X-RateLimit-Remaining", "3")
+		w.Header().Set("X-RateLimit-Reset", "1800000000")
+		_, _ = w.Write([]byte(`{"collection":"azuki","unmapped":true}`))
+	})
+
+	cli := NewClient(
+		WithBaseURL(srv.URL, ""),
+		WithRetryPolicy(&BackoffRetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+	)
+
+	var meta ResponseMeta
+	_, err := cli.GetCollection(context.Background(), "azuki", UseResponseMeta(&meta))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+	assert.Equal(t, "req-1", meta.RequestID)
+	assert.Equal(t, 2, meta.Attempts)
+	assert.Equal(t, 1, meta.Retries)
+	assert.Equal(t, RateLimitHeaders{Limit: 4, Remaining: 3, Reset: time.Unix(1800000000, 0)}, meta.RateLimit)
+	assert.JSONEq(t, `{"collection":"azuki","unmapped":true}`, string(meta.Body))
+}
+
+func TestUseResponseMetaOnFailure(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cf-Ray", "ray-1")
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"errors":["slow down"]}`))
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithRetryPolicy(NoRetryPolicy()))
+
+	var meta ResponseMeta
+	_, err := cli.GetTraits(context.Background(), "azuki", UseResponseMeta(&meta))
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	assert.Equal(t, http.StatusTooManyRequests, meta.StatusCode)
+	assert.Equal(t, "ray-1", meta.RequestID)
+	assert.Equal(t, 7*time.Second, meta.RateLimit.RetryAfter)
+	assert.Equal(t, -1, meta.RateLimit.Remaining)
+	assert.Zero(t, meta.Retries)
+	assert.Equal(t, `{"errors":["slow down"]}`, string(meta.Body))
+}
@@ -0,0 +1,145 @@
+package openseaapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ prometheus.Collector = (*MetricsCollector)(nil)
+
+// MetricsCollector is a prometheus.Collector fed by the clients configured WithMetrics.
+// It can be shared by several clients and must be registered by the caller, e.g.
+//
+//	metrics := NewMetricsCollector("")
+//	prometheus.MustRegister(metrics)
+//	cli := NewClient(WithMetrics(metrics))
+type MetricsCollector struct {
+	requests      *prometheus.CounterVec
+	latency       *prometheus.HistogramVec
+	retries       *prometheus.CounterVec
+	rateLimited   *prometheus.CounterVec
+	inFlight      *prometheus.GaugeVec
+	bytesReceived *prometheus.CounterVec
+	coalesced     *prometheus.CounterVec
+}
+
+// NewMetricsCollector creates the collector, namespace defaults to "opensea".
+func NewMetricsCollector(namespace string) *MetricsCollector {
+	if namespace == "" {
+		namespace = "opensea"
+	}
+
+	labels := []string{"endpoint", "chain", "testnets"}
+
+	return &MetricsCollector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of calls to the OpenSea API by status class (2xx, 4xx, 5xx or error).",
+		}, append(labels, "status_class")),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the calls to the OpenSea API, retries included.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+		}, labels),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Number of http attempts retried by the retry policy.",
+		}, labels),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rate_limited_total",
+			Help:      "Number of http attempts answered with 429 Too Many Requests.",
+		}, labels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "requests_in_flight",
+			Help:      "Number of calls to the OpenSea API currently in flight.",
+		}, []string{"endpoint"}),
+		bytesReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "response_bytes_total",
+			Help:      "Size of the response bodies received from the OpenSea API.",
+		}, labels),
+		coalesced: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "coalesced_requests_total",
+			Help:      "Number of calls served by an identical in-flight call instead of a request of their own, see WithCoalescing.",
+		}, labels),
+	}
+}
+
+func (m *MetricsCollector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.requests, m.latency, m.retries, m.rateLimited, m.inFlight, m.bytesReceived, m.coalesced}
+}
+
+func (m *MetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+func (m *MetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func metricsLabels(req *Request) []string {
+	return []string{req.Endpoint, req.Chain.Value(), strconv.FormatBool(req.Testnets)}
+}
+
+// statusClass returns the class of the status of a call: 2xx, 4xx, 5xx or error when no response was received.
+func statusClass(resp *Response, err error) string {
+	if resp == nil {
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			return "error"
+		}
+		return strconv.Itoa(apiErr.StatusCode/100) + "xx"
+	}
+	return strconv.Itoa(resp.StatusCode/100) + "xx"
+}
+
+// observeAttempt records an http attempt made by doWithRetry.
+func (m *MetricsCollector) observeAttempt(req *Request, attempt int, resp *http.Response) {
+	labels := metricsLabels(req)
+	if attempt > 1 {
+		m.retries.WithLabelValues(labels...).Inc()
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		m.rateLimited.WithLabelValues(labels...).Inc()
+	}
+}
+
+// observeCoalesced records a call which joined an identical in-flight call.
+func (m *MetricsCollector) observeCoalesced(req *Request) {
+	m.coalesced.WithLabelValues(metricsLabels(req)...).Inc()
+}
+
+// instrument records every call going through the doer.
+func (m *MetricsCollector) instrument(next Doer) Doer {
+	return DoerFunc(func(req *Request) (*Response, error) {
+		inFlight := m.inFlight.WithLabelValues(req.Endpoint)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		start := time.Now()
+		resp, err := next.Do(req)
+
+		labels := metricsLabels(req)
+		m.latency.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+		m.requests.WithLabelValues(append(labels, statusClass(resp, err))...).Inc()
+		if resp != nil {
+			m.bytesReceived.WithLabelValues(labels...).Add(float64(len(resp.Body)))
+		}
+
+		return resp, err
+	})
+}
@@ -0,0 +1,71 @@
+package openseaapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMetrics(t *testing.T) {
+	var attempts atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/missing"):
+			w.WriteHeader(http.StatusNotFound)
+		case attempts.Add(1) == 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(`{"collection":"azuki"}`))
+		}
+	})
+
+	metrics := NewMetricsCollector("")
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(metrics))
+
+	cli := NewClient(
+		WithBaseURL(srv.URL, ""),
+		WithMetrics(metrics),
+		WithRetryPolicy(&BackoffRetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+	)
+
+	ctx := context.Background()
+	_, err := cli.GetCollection(ctx, "azuki")
+	require.NoError(t, err)
+	_, err = cli.GetCollection(ctx, "missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(EndpointGetCollection, "", "false", "2xx")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(EndpointGetCollection, "", "false", "4xx")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.retries.WithLabelValues(EndpointGetCollection, "", "false")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.rateLimited.WithLabelValues(EndpointGetCollection, "", "false")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.inFlight.WithLabelValues(EndpointGetCollection)))
+	assert.Equal(t, float64(len(`{"collection":"azuki"}`)),
+		testutil.ToFloat64(metrics.bytesReceived.WithLabelValues(EndpointGetCollection, "", "false")))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics, "opensea_request_duration_seconds"))
+
+	problems, err := testutil.GatherAndLint(registry)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestMetricsStatusClassTransportError(t *testing.T) {
+	metrics := NewMetricsCollector("test")
+	cli := NewClient(
+		WithBaseURL("http://127.0.0.1:1", ""),
+		WithMetrics(metrics),
+		WithRetryPolicy(NoRetryPolicy()),
+	)
+
+	_, err := cli.GetCollection(context.Background(), "azuki")
+	require.Error(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(EndpointGetCollection, "", "false", "error")))
+}
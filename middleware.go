@@ -0,0 +1,135 @@
+package openseaapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/xTransact/errx/v3"
+
+	"github.com/xTransact/openseaapi/chain"
+)
+
+// Endpoint names reported to middlewares through Request.Endpoint, one per Servicer method.
+const (
+	EndpointGetAccount                 = "GetAccount"
+	EndpointListNftsByAccount          = "ListNftsByAccount"
+	EndpointGetContract                = "GetContract"
+	EndpointListNftsByContract         = "ListNftsByContract"
+	EndpointGetNft                     = "GetNft"
+	EndpointRefreshNftMetadata         = "RefreshNftMetadata"
+	EndpointValidateNftMetadata        = "ValidateNftMetadata"
+	EndpointListNftsByCollection       = "ListNftsByCollection"
+	EndpointListCollections            = "ListCollections"
+	EndpointGetCollection              = "GetCollection"
+	EndpointGetTraits                  = "GetTraits"
+	EndpointGetPaymentToken            = "GetPaymentToken"
+	EndpointGetCollectionStats         = "GetCollectionStats"
+	EndpointListEventsByAccount        = "ListEventsByAccount"
+	EndpointListEventsByNft            = "ListEventsByNft"
+	EndpointListEventsByCollection     = "ListEventsByCollection"
+	EndpointBuildOffer                 = "BuildOffer"
+	EndpointGetCollectionOffers        = "GetCollectionOffers"
+	EndpointCreateCriteriaOffer        = "CreateCriteriaOffer"
+	EndpointCreateIndividualOffer      = "CreateIndividualOffer"
+	EndpointCreateListing              = "CreateListing"
+	EndpointFulfillListing             = "FulfillListing"
+	EndpointFulfillOffer               = "FulfillOffer"
+	EndpointGetAllListingsByCollection = "GetAllListingsByCollection"
+	EndpointGetAllCollectionOffers     = "GetAllCollectionOffers"
+	EndpointGetIndividualOffers        = "GetIndividualOffers"
+	EndpointGetListings                = "GetListings"
+	EndpointGetOrder                   = "GetOrder"
+	EndpointCancelOrder                = "CancelOrder"
+	EndpointGetTraitOffers             = "GetTraitOffers"
+	EndpointGetBestListing             = "GetBestListing"
+	EndpointGetBestListings            = "GetBestListings"
+	EndpointGetBestOffer               = "GetBestOffer"
+)
+
+// Request is a call to the OpenSea API as seen by middlewares.
+type Request struct {
+	// Endpoint is the name of the Servicer method, e.g. "GetNft". See the Endpoint constants.
+	Endpoint string
+	// Chain is the chain of the call, zero when the endpoint is not scoped to a chain.
+	Chain chain.Chain
+	// Testnets reports whether the call targets the testnets API.
+	Testnets bool
+	// Payload is the argument the Servicer method was called with:
+	// the payload struct, or the collection slug / address for the endpoints taking one.
+	Payload any
+	// HTTPRequest is the http request about to be sent. Middlewares may add headers to it.
+	HTTPRequest *http.Request
+
+	options *requestOptions
+	// attempts is the number of http attempts made for the call, set once it is sent.
+	attempts int
+}
+
+// Context returns the context of the call.
+func (r *Request) Context() context.Context {
+	return r.HTTPRequest.Context()
+}
+
+// Response is the answer of the OpenSea API as seen by middlewares.
+type Response struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Header is the header of the response.
+	Header http.Header
+	// Body is the raw body of the response.
+	Body []byte
+}
+
+// Doer sends a call to the OpenSea API.
+// On a non-200 status it returns both the response and an *APIError.
+type Doer interface {
+	Do(req *Request) (*Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(req *Request) (*Response, error)
+
+func (f DoerFunc) Do(req *Request) (*Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to add a cross-cutting concern, e.g. logging, metrics, auditing,
+// header injection or fault injection. A middleware may answer without calling next.
+type Middleware func(next Doer) Doer
+
+// chainMiddlewares wraps the doer so that the first middleware is the outermost one.
+func chainMiddlewares(doer Doer, middlewares []Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			doer = middlewares[i](doer)
+		}
+	}
+	return doer
+}
+
+// send is the innermost Doer: it sends the http request, retrying it according to the policy.
+func (c *client) send(req *Request) (*Response, error) {
+	res, attempts, err := c.doWithRetry(req)
+	req.attempts = attempts
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errx.Wrapf(err, "%s: read response body", res.Status)
+	}
+
+	resp := &Response{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       body,
+	}
+	if res.StatusCode != http.StatusOK {
+		return resp, errx.WithStack(newAPIError(req.HTTPRequest, res, body))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,75 @@
+package openseaapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var gotHeader string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Trace")
+		_, _ = w.Write([]byte(`{"nft":{"identifier":"1"}}`))
+	})
+
+	var order []string
+	var seen *Request
+	outer := func(next Doer) Doer {
+		return DoerFunc(func(req *Request) (*Response, error) {
+			order = append(order, "outer")
+			seen = req
+			req.HTTPRequest.Header.Set("X-Trace", "abc")
+			return next.Do(req)
+		})
+	}
+	inner := func(next Doer) Doer {
+		return DoerFunc(func(req *Request) (*Response, error) {
+			order = append(order, "inner")
+			resp, err := next.Do(req)
+			if err == nil {
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+			}
+			return resp, err
+		})
+	}
+
+	payload := &openseamodels.GetNftPayload{
+		Address:    chain.RequireAddress("0xb31d6b5516eed64a874e9f7ab605e359e20b645f"),
+		Identifier: "1",
+	}
+	cli := NewClient(WithBaseURL("", srv.URL), WithMiddleware(outer, inner))
+	resp, err := cli.GetNft(context.Background(), chain.Sepolia, payload)
+	require.NoError(t, err)
+	assert.Equal(t, "1", resp.Nft.Identifier)
+
+	assert.Equal(t, []string{"outer", "inner"}, order)
+	assert.Equal(t, "abc", gotHeader)
+	require.NotNil(t, seen)
+	assert.Equal(t, EndpointGetNft, seen.Endpoint)
+	assert.Equal(t, chain.Sepolia, seen.Chain)
+	assert.True(t, seen.Testnets)
+	assert.Same(t, payload, seen.Payload)
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	injected := errors.New("injected")
+	cli := NewClient(WithMiddleware(func(next Doer) Doer {
+		return DoerFunc(func(req *Request) (*Response, error) {
+			if req.Endpoint == EndpointGetCollection {
+				return nil, injected
+			}
+			return next.Do(req)
+		})
+	}))
+
+	_, err := cli.GetCollection(context.Background(), "azuki")
+	assert.ErrorIs(t, err, injected)
+}
@@ -7,11 +7,9 @@ import (
 	"net/http"
 	neturl "net/url"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/xTransact/errx/v3"
 
 	"github.com/xTransact/openseaapi/chain"
-	"github.com/xTransact/openseaapi/openseaapiutils"
 	"github.com/xTransact/openseaapi/openseamodels"
 )
 
@@ -23,18 +21,28 @@ import (
 // @param limit: The number of NFTs to return. Must be between 1 and 200. Default: 50
 // @param next: The cursor for the next page of results. This is returned from a previous request.
 // DOC: https://docs.opensea.io/reference/list_nfts_by_account
-func (c *client) ListNftsByAccount(ctx context.Context, ch chain.Chain,
-	payload *openseamodels.GetNftsByAccountPayload) (resp *openseamodels.NftsResponse, err error) {
+func (c *client) ListNftsByAccount(ctx context.Context, ch chain.Chain, payload *openseamodels.GetNftsByAccountPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.NftsResponse, err error) {
 
 	if err = payload.Validate(); err != nil {
 		return nil, errx.Wrap(err, "invalid payload")
 	}
+	if err = validateAddress(ch, payload.Address); err != nil {
+		return nil, errx.Wrap(err, "invalid payload")
+	}
+
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointListNftsByAccount, ch, payload
 
 	// GET /api/v2/chain/{chain}/account/{address}/nfts
 	url := fmt.Sprintf("%s/api/v2/chain/%s/account/%s/nfts",
-		openseaapiutils.GetBaseURLByChain(ch), ch.Value(), payload.Address.String())
+		c.baseURL(o), ch.Value(), payload.Address.String())
 
-	return c.getNfts(ctx, url, payload.ToQuery(), ch.IsTestNet())
+	return c.getNfts(ctx, url, payload.ToQuery(), o)
 }
 
 // ListNftsByContract gets multiple NFTs for a smart contract.
@@ -43,18 +51,28 @@ func (c *client) ListNftsByAccount(ctx context.Context, ch chain.Chain,
 // @param limit: The number of NFTs to return. Must be between 1 and 200. Default: 50
 // @param next: The cursor for the next page of results. This is returned from a previous request.
 // DOC: https://docs.opensea.io/reference/list_nfts_by_contract
-func (c *client) ListNftsByContract(ctx context.Context, ch chain.Chain,
-	payload *openseamodels.GetNftsByContractPayload) (resp *openseamodels.NftsResponse, err error) {
+func (c *client) ListNftsByContract(ctx context.Context, ch chain.Chain, payload *openseamodels.GetNftsByContractPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.NftsResponse, err error) {
 
 	if err = payload.Validate(); err != nil {
 		return nil, errx.Wrap(err, "invalid payload")
 	}
+	if err = validateAddress(ch, payload.Address); err != nil {
+		return nil, errx.Wrap(err, "invalid payload")
+	}
+
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointListNftsByContract, ch, payload
 
 	// GET /api/v2/chain/{chain}/contract/{address}/nfts
 	url := fmt.Sprintf("%s/api/v2/chain/%s/contract/%s/nfts",
-		openseaapiutils.GetBaseURLByChain(ch), ch.Value(), payload.Address.String())
+		c.baseURL(o), ch.Value(), payload.Address.String())
 
-	return c.getNfts(ctx, url, payload.ToQuery(), ch.IsTestNet())
+	return c.getNfts(ctx, url, payload.ToQuery(), o)
 }
 
 // ListNftsByCollection gets multiple NFTs for a collection.
@@ -70,6 +88,7 @@ func (c *client) ListNftsByCollection(ctx context.Context, payload *openseamodel
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointListNftsByCollection, payload
 
 	if err = payload.Validate(); err != nil {
 		return nil, errx.Wrap(err, "invalid payload")
@@ -77,9 +96,9 @@ func (c *client) ListNftsByCollection(ctx context.Context, payload *openseamodel
 
 	// GET /api/v2/collection/{collection_slug}/nfts
 	url := fmt.Sprintf("%s/api/v2/collection/%s/nfts",
-		openseaapiutils.GetBaseURL(o.testnets), payload.CollectionSlug)
+		c.baseURL(o), payload.CollectionSlug)
 
-	return c.getNfts(ctx, url, payload.ToQuery(), o.testnets)
+	return c.getNfts(ctx, url, payload.ToQuery(), o)
 }
 
 // GetNft gets metadata, traits, ownership information, and rarity for a single NFT.
@@ -87,16 +106,25 @@ func (c *client) ListNftsByCollection(ctx context.Context, payload *openseamodel
 // @param address: required: The unique public blockchain identifier for the contract.
 // @param identifier: required: The NFT token id.
 // DOC: https://docs.opensea.io/reference/get_nft
-func (c *client) GetNft(ctx context.Context, ch chain.Chain,
-	payload *openseamodels.GetNftPayload) (resp *openseamodels.NftResponse, err error) {
+func (c *client) GetNft(ctx context.Context, ch chain.Chain, payload *openseamodels.GetNftPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.NftResponse, err error) {
 
 	if err = payload.Validate(); err != nil {
 		return nil, errx.Wrap(err, "invalid payload")
 	}
+	if err = validateAddress(ch, payload.Address); err != nil {
+		return nil, errx.Wrap(err, "invalid payload")
+	}
+
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointGetNft, ch, payload
 
 	// GET /api/v2/chain/{chain}/contract/{address}/nfts/{identifier}
-	url := fmt.Sprintf("%s/api/v2/chain/%s/contract/%s/nfts/%s",
-		openseaapiutils.GetBaseURLByChain(ch), ch.Value(), payload.Address.String(), payload.Identifier)
+	url := c.nftURL(o, ch, payload.Address, payload.Identifier)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -104,7 +132,7 @@ func (c *client) GetNft(ctx context.Context, ch chain.Chain,
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, ch.IsTestNet())
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -122,31 +150,95 @@ func (c *client) GetNft(ctx context.Context, ch chain.Chain,
 // @param address: required: The unique public blockchain identifier for the contract.
 // @param identifier: required: The NFT token id.
 // DOC: https://docs.opensea.io/reference/refresh_nft
-func (c *client) RefreshNftMetadata(ctx context.Context,
-	ch chain.Chain, address common.Address, identifier string) error {
+func (c *client) RefreshNftMetadata(ctx context.Context, ch chain.Chain, address chain.Address, identifier string,
+	opts ...RequestOptionFn) error {
 
-	if !common.IsHexAddress(address.String()) {
-		return errx.New("invalid address")
+	if err := validateAddress(ch, address); err != nil {
+		return err
 	}
 	if identifier == "" {
 		return errx.New("identifier must not be empty")
 	}
 
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain = EndpointRefreshNftMetadata, ch
+	o.payload = &openseamodels.GetNftPayload{Address: address, Identifier: identifier}
+
 	// POST /api/v2/chain/{chain}/contract/{address}/nfts/{identifier}/refresh
-	url := fmt.Sprintf("%s/api/v2/chain/%s/contract/%s/nfts/%s/refresh",
-		openseaapiutils.GetBaseURLByChain(ch), ch.Value(), address.String(), identifier)
+	url := c.nftURL(o, ch, address, identifier) + "/refresh"
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
 	if err != nil {
 		return errx.WithStack(err)
 	}
 
-	_, err = c.doRequest(req, ch.IsTestNet())
+	_, err = c.doRequest(req, o)
 	return errx.WithStack(err)
 }
 
+// ValidateNftMetadata fetches and parses the metadata of a single NFT the way OpenSea would ingest it,
+// without updating the NFT: check it before calling RefreshNftMetadata.
+// @param ch (chain.Chain): required: The blockchain on which to filter the results.
+// @param address: required: The unique public blockchain identifier for the contract.
+// @param identifier: required: The NFT token id.
+// @param ignoreCachedItemUrls: If true, the image and animation urls are fetched again instead of being read from the OpenSea cache.
+// DOC: https://docs.opensea.io/reference/validate_nft_metadata
+func (c *client) ValidateNftMetadata(ctx context.Context, ch chain.Chain, payload *openseamodels.ValidateNftMetadataPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.ValidateNftMetadataResponse, err error) {
+
+	if err = payload.Validate(); err != nil {
+		return nil, errx.Wrap(err, "invalid payload")
+	}
+	if err = validateAddress(ch, payload.Address); err != nil {
+		return nil, errx.Wrap(err, "invalid payload")
+	}
+
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointValidateNftMetadata, ch, payload
+
+	// POST /api/v2/chain/{chain}/contract/{address}/nfts/{identifier}/validate-metadata
+	url := c.nftURL(o, ch, payload.Address, payload.Identifier) + "/validate-metadata"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+
+	qs := payload.ToQuery()
+	if len(qs) > 0 {
+		req.URL.RawQuery = qs.Encode()
+	}
+
+	c.acceptJson(req)
+	body, err := c.doRequest(req, o)
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+
+	resp = new(openseamodels.ValidateNftMetadataResponse)
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errx.Wrap(err, "unmarshal response body")
+	}
+
+	return resp, nil
+}
+
+// nftURL returns the URL of a single NFT, /api/v2/chain/{chain}/contract/{address}/nfts/{identifier}
+func (c *client) nftURL(o *requestOptions, ch chain.Chain, address chain.Address, identifier string) string {
+	return fmt.Sprintf("%s/api/v2/chain/%s/contract/%s/nfts/%s",
+		c.baseURL(o), ch.Value(), address.String(), identifier)
+}
+
 func (c *client) getNfts(ctx context.Context, url string,
-	query neturl.Values, testnets bool) (resp *openseamodels.NftsResponse, err error) {
+	query neturl.Values, o *requestOptions) (resp *openseamodels.NftsResponse, err error) {
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -158,7 +250,7 @@ func (c *client) getNfts(ctx context.Context, url string,
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
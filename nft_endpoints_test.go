@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"testing"
 
@@ -17,7 +18,7 @@ import (
 
 func TestGetAccount(t *testing.T) {
 	ctx := context.Background()
-	testAddr := common.HexToAddress(os.Getenv("WALLET_ADDRESS"))
+	testAddr := chain.EVMAddress(common.HexToAddress(os.Getenv("WALLET_ADDRESS")))
 	testApiKey := os.Getenv("OPENSEA_API_KEY")
 
 	cli := NewClient(WithApiKey(testApiKey))
@@ -30,7 +31,7 @@ func TestGetAccount(t *testing.T) {
 
 func TestGetGoerliAccount(t *testing.T) {
 	ctx := context.Background()
-	testAddr := common.HexToAddress(os.Getenv("WALLET_ADDRESS"))
+	testAddr := chain.EVMAddress(common.HexToAddress(os.Getenv("WALLET_ADDRESS")))
 
 	cli := NewClient()
 	resp, err := cli.GetAccount(ctx, testAddr, UseTestnets())
@@ -42,7 +43,7 @@ func TestGetGoerliAccount(t *testing.T) {
 
 func TestGetNftsByAccount(t *testing.T) {
 	ctx := context.Background()
-	testAddr := common.HexToAddress(os.Getenv("WALLET_ADDRESS_ALPHA"))
+	testAddr := chain.EVMAddress(common.HexToAddress(os.Getenv("WALLET_ADDRESS_ALPHA")))
 	testApiKey := os.Getenv("OPENSEA_API_KEY")
 
 	cli := NewClient(WithApiKey(testApiKey))
@@ -69,7 +70,7 @@ func TestGetNft(t *testing.T) {
 	ctx := context.Background()
 	testApiKey := os.Getenv("OPENSEA_API_KEY")
 	// azuki
-	contract := common.HexToAddress("0xed5af388653567af2f388e6224dc7c4b3241c544")
+	contract := chain.RequireAddress("0xed5af388653567af2f388e6224dc7c4b3241c544")
 	const tokenID = "1234"
 
 	cli := NewClient(WithApiKey(testApiKey))
@@ -83,12 +84,14 @@ func TestGetNft(t *testing.T) {
 	require.NotNil(t, resp.Nft)
 
 	assert.Equal(t, tokenID, resp.Nft.Identifier)
-	assert.Equal(t, contract, common.HexToAddress(resp.Nft.Contract))
+	nftContract, err := chain.ParseAddress(resp.Nft.Contract)
+	require.NoError(t, err)
+	assert.Equal(t, contract, nftContract)
 }
 
 func TestGetGoerliNft(t *testing.T) {
 	ctx := context.Background()
-	testCollectionContract := common.HexToAddress(os.Getenv("TEST_COLLECTION_CONTRACT"))
+	testCollectionContract := chain.EVMAddress(common.HexToAddress(os.Getenv("TEST_COLLECTION_CONTRACT")))
 	const tokenID = "1"
 
 	cli := NewClient()
@@ -102,5 +105,91 @@ func TestGetGoerliNft(t *testing.T) {
 	require.NotNil(t, resp.Nft)
 
 	assert.Equal(t, tokenID, resp.Nft.Identifier)
-	assert.Equal(t, testCollectionContract, common.HexToAddress(resp.Nft.Contract))
+	nftContract, err := chain.ParseAddress(resp.Nft.Contract)
+	require.NoError(t, err)
+	assert.Equal(t, testCollectionContract, nftContract)
+}
+
+func TestValidateNftMetadata(t *testing.T) {
+	var method, path, query string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"token_uri":"ipfs://meta/7","metadata":{"name":"Azuki #7","image_url":"ipfs://img/7",
+			"traits":[{"trait_type":"Background","value":"Red"}]},
+			"errors":["animation_url is unreachable",{"field":"image_url","message":"unsupported format"}]}`))
+	})
+
+	addr := chain.RequireAddress("0xED5AF388653567Af2F388E6224dC7C4b3241C544")
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL))
+	resp, err := cli.ValidateNftMetadata(context.Background(), chain.Ethereum, &openseamodels.ValidateNftMetadataPayload{
+		GetNftPayload:        openseamodels.GetNftPayload{Address: addr, Identifier: "7"},
+		IgnoreCachedItemUrls: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/api/v2/chain/ethereum/contract/"+addr.String()+"/nfts/7/validate-metadata", path)
+	assert.Equal(t, "ignoreCachedItemUrls=true", query)
+
+	assert.Equal(t, "ipfs://meta/7", resp.TokenUri)
+	require.NotNil(t, resp.Metadata)
+	assert.Equal(t, "Azuki #7", resp.Metadata.Name)
+	require.Len(t, resp.Metadata.Traits, 1)
+	assert.Equal(t, "Background", resp.Metadata.Traits[0].TraitType)
+	assert.False(t, resp.Valid())
+	require.Len(t, resp.Errors, 2)
+	assert.Equal(t, "animation_url is unreachable", resp.Errors[0].Error())
+	assert.Equal(t, "image_url: unsupported format", resp.Errors[1].Error())
+
+	_, err = cli.ValidateNftMetadata(context.Background(), chain.Ethereum, &openseamodels.ValidateNftMetadataPayload{
+		GetNftPayload: openseamodels.GetNftPayload{Address: addr},
+	})
+	assert.Error(t, err)
+}
+
+func TestSolanaAddress(t *testing.T) {
+	var paths []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"nfts":[{"identifier":"7","contract":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"}],
+			"owners":[{"address":"So11111111111111111111111111111111111111112","quantity":1}]}`))
+	})
+
+	ctx := context.Background()
+	owner := chain.RequireAddress("So11111111111111111111111111111111111111112")
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL))
+
+	_, err := cli.ListNftsByAccount(ctx, chain.Solana, &openseamodels.GetNftsByAccountPayload{
+		GetNftsBasePayload: &openseamodels.GetNftsBasePayload{
+			BaseQueryParams: &openseamodels.BaseQueryParams{},
+			Address:         owner,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api/v2/chain/solana/account/So11111111111111111111111111111111111111112/nfts"}, paths)
+
+	// EVM 地址不能用于 Solana，反之亦然
+	_, err = cli.ListNftsByAccount(ctx, chain.Solana, &openseamodels.GetNftsByAccountPayload{
+		GetNftsBasePayload: &openseamodels.GetNftsBasePayload{
+			BaseQueryParams: &openseamodels.BaseQueryParams{},
+			Address:         chain.RequireAddress("0xED5AF388653567Af2F388E6224dC7C4b3241C544"),
+		},
+	})
+	assert.Error(t, err)
+	err = cli.RefreshNftMetadata(ctx, chain.Ethereum, owner, "7")
+	assert.Error(t, err)
+	assert.Len(t, paths, 1)
+
+	// 账户可以是任意链的地址，但格式异常的地址不发出请求
+	_, err = cli.GetAccount(ctx, owner)
+	require.NoError(t, err)
+	var o openseamodels.Owner
+	require.NoError(t, json.Unmarshal([]byte(`{"address":"0x1234","quantity":1}`), &o))
+	_, err = cli.GetAccount(ctx, o.Address)
+	assert.Error(t, err)
+	_, err = cli.GetAccount(ctx, chain.Address{})
+	assert.Error(t, err)
+	assert.Len(t, paths, 2)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"address":"So11111111111111111111111111111111111111112","quantity":1}`), &o))
+	assert.Equal(t, owner, o.Address)
 }
@@ -10,7 +10,6 @@ import (
 	"github.com/xTransact/errx/v3"
 
 	"github.com/xTransact/openseaapi/chain"
-	"github.com/xTransact/openseaapi/openseaapiutils"
 	"github.com/xTransact/openseaapi/openseaconsts"
 	"github.com/xTransact/openseaapi/openseamodels"
 )
@@ -24,6 +23,7 @@ func (c *client) BuildOffer(ctx context.Context, payload *openseamodels.BuildOff
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointBuildOffer, payload
 
 	if err = payload.Validate(); err != nil {
 		return nil, err
@@ -35,7 +35,7 @@ func (c *client) BuildOffer(ctx context.Context, payload *openseamodels.BuildOff
 	}
 
 	// POST /api/v2/offers/build
-	url := fmt.Sprintf("%s/api/v2/offers/build", openseaapiutils.GetBaseURL(o.testnets))
+	url := fmt.Sprintf("%s/api/v2/offers/build", c.baseURL(o))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payloadData))
 	if err != nil {
@@ -44,7 +44,7 @@ func (c *client) BuildOffer(ctx context.Context, payload *openseamodels.BuildOff
 
 	c.acceptJson(req)
 	c.contentTypeJson(req)
-	body, err := c.doRequest(req, o.testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -66,6 +66,7 @@ func (c *client) CreateCriteriaOffer(ctx context.Context, payload *openseamodels
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointCreateCriteriaOffer, payload
 
 	if err = payload.Validate(); err != nil {
 		return nil, err
@@ -77,7 +78,7 @@ func (c *client) CreateCriteriaOffer(ctx context.Context, payload *openseamodels
 	}
 
 	// POST /api/v2/offers
-	url := fmt.Sprintf("%s/api/v2/offers", openseaapiutils.GetBaseURL(o.testnets))
+	url := fmt.Sprintf("%s/api/v2/offers", c.baseURL(o))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payloadData))
 	if err != nil {
@@ -86,7 +87,7 @@ func (c *client) CreateCriteriaOffer(ctx context.Context, payload *openseamodels
 
 	c.acceptJson(req)
 	c.contentTypeJson(req)
-	body, err := c.doRequest(req, o.testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -101,9 +102,12 @@ func (c *client) CreateCriteriaOffer(ctx context.Context, payload *openseamodels
 
 // CreateIndividualOffer creates an offer to purchase a single NFT (ERC721 or ERC1155).
 // DOC: https://docs.opensea.io/reference/post_offer
-func (c *client) CreateIndividualOffer(ctx context.Context, ch chain.Chain,
-	payload *openseamodels.CreateOrderPayload) (resp *openseamodels.OrderResponse, err error) {
+func (c *client) CreateIndividualOffer(ctx context.Context, ch chain.Chain, payload *openseamodels.CreateOrderPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.OrderResponse, err error) {
 
+	if err = c.signOrder(ctx, ch, payload); err != nil {
+		return nil, errx.Wrap(err, "sign order")
+	}
 	if err = payload.Validate(); err != nil {
 		return nil, err
 	}
@@ -113,9 +117,16 @@ func (c *client) CreateIndividualOffer(ctx context.Context, ch chain.Chain,
 		return nil, errx.Wrap(err, "marshal payload")
 	}
 
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointCreateIndividualOffer, ch, payload
+
 	// POST /api/v2/orders/{chain}/{protocol}/offers
 	url := fmt.Sprintf("%s/api/v2/orders/%s/%s/offers",
-		openseaapiutils.GetBaseURLByChain(ch), ch.Value(), openseaconsts.ProtocolName)
+		c.baseURL(o), ch.Value(), openseaconsts.ProtocolName)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payloadData))
 	if err != nil {
@@ -124,7 +135,7 @@ func (c *client) CreateIndividualOffer(ctx context.Context, ch chain.Chain,
 
 	c.acceptJson(req)
 	c.contentTypeJson(req)
-	body, err := c.doRequest(req, ch.IsTestNet())
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -146,10 +157,11 @@ func (c *client) GetCollectionOffers(ctx context.Context, collectionSlug string,
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointGetCollectionOffers, collectionSlug
 
 	// GET /api/v2/offers/collection/{collection_slug}
 	url := fmt.Sprintf("%s/api/v2/offers/collection/%s",
-		openseaapiutils.GetBaseURL(o.testnets), collectionSlug)
+		c.baseURL(o), collectionSlug)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -157,7 +169,7 @@ func (c *client) GetCollectionOffers(ctx context.Context, collectionSlug string,
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, o.testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -179,6 +191,7 @@ func (c *client) GetAllCollectionOffers(ctx context.Context, payload *openseamod
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointGetAllCollectionOffers, payload
 
 	if err = payload.Validate(); err != nil {
 		return nil, errx.Wrap(err, "invalid payload")
@@ -186,7 +199,7 @@ func (c *client) GetAllCollectionOffers(ctx context.Context, payload *openseamod
 
 	// GET /api/v2/offers/collection/{collection_slug}/all
 	url := fmt.Sprintf("%s/api/v2/offers/collection/%s/all",
-		openseaapiutils.GetBaseURL(o.testnets), payload.CollectionSlug)
+		c.baseURL(o), payload.CollectionSlug)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -199,7 +212,7 @@ func (c *client) GetAllCollectionOffers(ctx context.Context, payload *openseamod
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, o.testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -214,16 +227,23 @@ func (c *client) GetAllCollectionOffers(ctx context.Context, payload *openseamod
 
 // GetIndividualOffers gets the active, valid individual offers. This does not include criteria offers.
 // DOC: https://docs.opensea.io/reference/get_offers
-func (c *client) GetIndividualOffers(ctx context.Context, ch chain.Chain,
-	payload *openseamodels.OrderPayload) (resp *openseamodels.OrdersResponse, err error) {
+func (c *client) GetIndividualOffers(ctx context.Context, ch chain.Chain, payload *openseamodels.OrderPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.OrdersResponse, err error) {
 
 	if err = payload.Validate(); err != nil {
 		return nil, errx.Wrap(err, "invalid payload")
 	}
 
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointGetIndividualOffers, ch, payload
+
 	// GET /api/v2/orders/{chain}/{protocol}/offers
 	url := fmt.Sprintf("%s/api/v2/orders/%s/%s/offers",
-		openseaapiutils.GetBaseURLByChain(ch), ch.Value(), openseaconsts.ProtocolName)
+		c.baseURL(o), ch.Value(), openseaconsts.ProtocolName)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -236,7 +256,7 @@ func (c *client) GetIndividualOffers(ctx context.Context, ch chain.Chain,
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, ch.IsTestNet())
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -258,6 +278,7 @@ func (c *client) GetTraitOffers(ctx context.Context, payload *openseamodels.GetT
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointGetTraitOffers, payload
 
 	if err = payload.Validate(); err != nil {
 		return nil, errx.Wrap(err, "invalid payload")
@@ -265,7 +286,7 @@ func (c *client) GetTraitOffers(ctx context.Context, payload *openseamodels.GetT
 
 	// GET /api/v2/offers/collection/{collection_slug}/traits
 	url := fmt.Sprintf("%s/api/v2/offers/collection/%s/traits",
-		openseaapiutils.GetBaseURL(o.testnets), payload.CollectionSlug)
+		c.baseURL(o), payload.CollectionSlug)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -278,7 +299,7 @@ func (c *client) GetTraitOffers(ctx context.Context, payload *openseamodels.GetT
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, o.testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -290,3 +311,53 @@ func (c *client) GetTraitOffers(ctx context.Context, payload *openseamodels.GetT
 
 	return resp, nil
 }
+
+// GetBestOffer gets the highest active, valid offer of a single NFT.
+// When OpenSea answers that it does not serve the endpoint, e.g. on a chain where it is unsupported, or with
+// ScanOrderPages, the offer is computed from the pages of GetAllCollectionOffers instead, among the individual
+// offers on the NFT and the offers on the whole collection: trait offers are skipped since the traits of the NFT
+// are unknown. See ScanOrderPages.
+// @Param collection_slug: required: Unique string to identify a collection on OpenSea.
+// @Param identifier: required: The NFT token id.
+// DOC: https://docs.opensea.io/reference/get_best_offer_on_nft_v2
+func (c *client) GetBestOffer(ctx context.Context, payload *openseamodels.GetBestOfferPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.OfferResponse, err error) {
+
+	if err = payload.Validate(); err != nil {
+		return nil, errx.Wrap(err, "invalid payload")
+	}
+
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.endpoint, o.payload = EndpointGetBestOffer, payload
+	if o.scanOrderPages {
+		return bestOfferFromPages(ctx, c, payload, opts...)
+	}
+
+	// GET /api/v2/offers/collection/{collection_slug}/nfts/{identifier}/best
+	url := fmt.Sprintf("%s/api/v2/offers/collection/%s/nfts/%s/best",
+		c.baseURL(o), payload.CollectionSlug, payload.Identifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+
+	c.acceptJson(req)
+	body, err := c.doRequest(req, o)
+	if err != nil {
+		if isBestOrderUnsupported(err) {
+			return bestOfferFromPages(ctx, c, payload, opts...)
+		}
+		return nil, errx.WithStack(err)
+	}
+
+	resp = new(openseamodels.OfferResponse)
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errx.Wrap(err, "unmarshal response body")
+	}
+
+	return resp, nil
+}
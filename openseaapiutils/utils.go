@@ -54,6 +54,9 @@ func ValidateJsonNumber(key string, value json.Number) error {
 	return nil
 }
 
+// ParseFailureResponse formats a non-200 response into an error.
+//
+// Deprecated: the client returns *openseaapi.APIError, which can be matched with errors.Is and errors.As.
 func ParseFailureResponse(resp *http.Response, respBody []byte) error {
 	if len(respBody) != 0 {
 		return errx.Errorf("%s: %s", resp.Status, respBody)
@@ -108,3 +108,18 @@ const (
 	EventTypeTransfer   EventType = "transfer"
 	EventTypeRedemption EventType = "redemption"
 )
+
+// StreamEventType is the type of event pushed by the OpenSea Stream API.
+type StreamEventType string
+
+const (
+	StreamEventItemListed          StreamEventType = "item_listed"
+	StreamEventItemSold            StreamEventType = "item_sold"
+	StreamEventItemTransferred     StreamEventType = "item_transferred"
+	StreamEventItemMetadataUpdated StreamEventType = "item_metadata_updated"
+	StreamEventItemCancelled       StreamEventType = "item_cancelled"
+	StreamEventItemReceivedOffer   StreamEventType = "item_received_offer"
+	StreamEventItemReceivedBid     StreamEventType = "item_received_bid"
+	StreamEventCollectionOffer     StreamEventType = "collection_offer"
+	StreamEventTraitOffer          StreamEventType = "trait_offer"
+)
@@ -1,12 +1,12 @@
 package openseamodels
 
 import (
-	"github.com/ethereum/go-ethereum/common"
+	"github.com/xTransact/openseaapi/chain"
 )
 
 type Account struct {
 	// The unique public blockchain identifier for the wallet.
-	Address common.Address `json:"address"`
+	Address chain.Address `json:"address"`
 	// The OpenSea account's username.
 	Username string `json:"username"`
 	// The OpenSea account's image url.
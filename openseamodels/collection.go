@@ -2,7 +2,9 @@ package openseamodels
 
 import (
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/shopspring/decimal"
@@ -85,8 +87,18 @@ func (p *CollectionPayload) Validate() error {
 	return p.BaseQueryParams.Validate()
 }
 
+// Orders of ListCollectionsPayload.OrderBy
+const (
+	CollectionsOrderByCreatedDate    = "created_date"
+	CollectionsOrderByOneDayChange   = "one_day_change"
+	CollectionsOrderBySevenDayVolume = "seven_day_volume"
+	CollectionsOrderBySevenDayChange = "seven_day_change"
+	CollectionsOrderByNumOwners      = "num_owners"
+	CollectionsOrderByMarketCap      = "market_cap"
+)
+
 type ListCollectionsPayload struct {
-	// The blockchain on which to filter the results
+	// The blockchain on which to filter the results. Empty lists the collections of all chains.
 	ChainIdentifier string `json:"chain_identifier"`
 	// If true, will return hidden collections. Default: false
 	IncludeHidden *bool `json:"include_hidden"`
@@ -94,17 +106,40 @@ type ListCollectionsPayload struct {
 	Limit int `json:"limit"`
 	// The cursor for the next page of results. This is returned from a previous request.
 	Next string `json:"next"`
+	// The order in which to sort the collections, see the CollectionsOrderBy constants. Default: created_date
+	OrderBy string `json:"order_by"`
+	// Filter to only include collections created by the given OpenSea username.
+	CreatorUsername string `json:"creator_username"`
+
+	// The following filters are not supported by the API and are applied locally on every page,
+	// which may then hold fewer collections than Limit.
+
+	// Filter to only include collections of the given category, e.g. pfps. Case insensitive.
+	Category string `json:"-"`
+	// Filter to only include collections with one of the given safelist statuses.
+	SafelistStatus []openseaenums.SafelistStatus `json:"-"`
+	// Filter to only include NSFW collections if true, or to exclude them if false.
+	Nsfw *bool `json:"-"`
 }
 
 func (p *ListCollectionsPayload) Validate() error {
-	if _, err := chain.NewFromString(p.ChainIdentifier); err != nil {
-		return err
+	if p.ChainIdentifier != "" {
+		if _, err := chain.NewFromString(p.ChainIdentifier); err != nil {
+			return err
+		}
 	}
 
 	if p.Limit != 0 && (p.Limit < 1 || p.Limit > 100) {
 		return errx.New("limit must be between 0 and 100")
 	}
 
+	if p.OrderBy != "" && !slices.Contains([]string{
+		CollectionsOrderByCreatedDate, CollectionsOrderByOneDayChange, CollectionsOrderBySevenDayVolume,
+		CollectionsOrderBySevenDayChange, CollectionsOrderByNumOwners, CollectionsOrderByMarketCap,
+	}, p.OrderBy) {
+		return errx.New("invalid order_by")
+	}
+
 	return nil
 }
 
@@ -123,10 +158,30 @@ func (p *ListCollectionsPayload) ToQuery() url.Values {
 	if p.Next != "" {
 		q.Set("next", p.Next)
 	}
+	if p.OrderBy != "" {
+		q.Set("order_by", p.OrderBy)
+	}
+	if p.CreatorUsername != "" {
+		q.Set("creator_username", p.CreatorUsername)
+	}
 
 	return q
 }
 
+// Match reports whether the collection passes the local filters: Category, SafelistStatus and Nsfw.
+func (p *ListCollectionsPayload) Match(c *Collection) bool {
+	if p.Category != "" && !strings.EqualFold(p.Category, c.Category) {
+		return false
+	}
+	if len(p.SafelistStatus) > 0 && !slices.Contains(p.SafelistStatus, c.SafelistStatus) {
+		return false
+	}
+	if p.Nsfw != nil && *p.Nsfw != c.IsNsfw {
+		return false
+	}
+	return true
+}
+
 type CollectionsResponse struct {
 	Collections []*Collection `json:"collections"`
 	Next        string        `json:"next"`
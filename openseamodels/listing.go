@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/xTransact/errx/v3"
 
 	"github.com/xTransact/openseaapi/openseaapiutils"
@@ -245,7 +246,7 @@ func (p *CreateOrderPayload) Validate() error {
 	if p.Parameters.ConduitKey == "" {
 		return errx.New("conduitKey must not be empty")
 	}
-	if p.Parameters.Counter == nil {
+	if p.Parameters.Counter == "" {
 		return errx.New("counter must not be empty")
 	}
 
@@ -319,12 +320,102 @@ type ListingsByCollectionResponse struct {
 }
 
 type CollectionListing struct {
-	OrderHash    string            `json:"order_hash"`
-	Type         openseaenums.Type `json:"type"`
-	Price        *Price            `json:"price"`
-	ProtocolData *ProtocolData     `json:"protocol_data"`
+	OrderHash       string            `json:"order_hash"`
+	Chain           string            `json:"chain"`
+	Type            openseaenums.Type `json:"type"`
+	Price           *Price            `json:"price"`
+	ProtocolData    *ProtocolData     `json:"protocol_data"`
+	ProtocolAddress string            `json:"protocol_address"`
 }
 
 type Price struct {
-	Current *Current `json:"currenty"`
+	Current *Current `json:"current"`
+}
+
+type GetBestListingPayload struct {
+	// Unique string to identify a collection on OpenSea.
+	// This can be found by visiting the collection on the OpenSea website and noting the last path parameter.
+	CollectionSlug string `json:"collection_slug"` // required
+	// The NFT token id.
+	Identifier string `json:"identifier"` // required
+	// If true, private listings will be included in the response. Default: false
+	IncludePrivateListings bool `json:"include_private_listings"`
+	// Contract is the NFT contract, only used by the order page scans of openseaapi.ScanOrderPages
+	// to tell apart the NFTs of a collection spanning several contracts.
+	Contract string `json:"-"`
+	// PaymentToken is the payment token the orders are compared in, only used by the order page scans
+	// of openseaapi.ScanOrderPages, which fail when the orders are in several tokens and it is empty.
+	// The zero address stands for the native token.
+	PaymentToken string `json:"-"`
+}
+
+func (p *GetBestListingPayload) Validate() error {
+	if p.CollectionSlug == "" {
+		return errx.New("illegal arguments: collection slug must not be empty")
+	}
+	if p.Identifier == "" {
+		return errx.New("illegal arguments: identifier must not be empty")
+	}
+	if p.Contract != "" && !common.IsHexAddress(p.Contract) {
+		return errx.New("illegal arguments: invalid contract")
+	}
+	if p.PaymentToken != "" && !common.IsHexAddress(p.PaymentToken) {
+		return errx.New("illegal arguments: invalid payment token")
+	}
+	return nil
+}
+
+func (p *GetBestListingPayload) ToQuery() url.Values {
+	q := make(url.Values)
+
+	if p.IncludePrivateListings {
+		q.Set("include_private_listings", "true")
+	}
+
+	return q
+}
+
+type GetBestListingsPayload struct {
+	// Unique string to identify a collection on OpenSea.
+	// This can be found by visiting the collection on the OpenSea website and noting the last path parameter.
+	CollectionSlug string `json:"collection_slug"` // required
+	// If true, private listings will be included in the response. Default: false
+	IncludePrivateListings bool `json:"include_private_listings"`
+	// The number of listings to return. Must be between 1 and 100. Default: 100
+	Limit int `json:"limit"`
+	// The cursor for the next page of results. This is returned from a previous request.
+	Next string `json:"next"`
+	// PaymentToken is the payment token the orders are compared in, only used by the order page scans
+	// of openseaapi.ScanOrderPages, which fail when the orders are in several tokens and it is empty.
+	// The zero address stands for the native token.
+	PaymentToken string `json:"-"`
+}
+
+func (p *GetBestListingsPayload) Validate() error {
+	if p.CollectionSlug == "" {
+		return errx.New("illegal arguments: collection slug must not be empty")
+	}
+	if p.Limit < 0 || p.Limit > 100 {
+		return errx.New("illegal arguments: limit must be between 0 and 100")
+	}
+	if p.PaymentToken != "" && !common.IsHexAddress(p.PaymentToken) {
+		return errx.New("illegal arguments: invalid payment token")
+	}
+	return nil
+}
+
+func (p *GetBestListingsPayload) ToQuery() url.Values {
+	q := make(url.Values)
+
+	if p.IncludePrivateListings {
+		q.Set("include_private_listings", "true")
+	}
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Next != "" {
+		q.Set("next", p.Next)
+	}
+
+	return q
 }
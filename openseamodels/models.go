@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/xTransact/errx/v3"
 
+	"github.com/xTransact/openseaapi/chain"
 	"github.com/xTransact/openseaapi/openseaapiutils"
 	"github.com/xTransact/openseaapi/openseaenums"
 )
@@ -35,7 +36,7 @@ type Current struct {
 
 type Owner struct {
 	// The unique public blockchain identifier for the owner wallet
-	Address common.Address `json:"address"`
+	Address chain.Address `json:"address"`
 	// The number of tokens owned
 	Quantity int `json:"quantity"`
 }
@@ -43,7 +44,7 @@ type Owner struct {
 // Contract defines the Contract's Addresses and Chain
 type Contract struct {
 	// The unique public blockchain identifier for the contract
-	Address common.Address `json:"address"`
+	Address chain.Address `json:"address"`
 	// The chain on which the contract exists
 	Chain string `json:"chain"`
 	// A unique string (collection slug) to identify a collection on OpenSea
@@ -248,7 +249,26 @@ type Parameters struct {
 	ConduitKey string `json:"conduitKey"`
 	// TotalOriginalConsiderationItems: required: Size of the consideration array.
 	TotalOriginalConsiderationItems json.Number `json:"totalOriginalConsiderationItems"`
-	Counter                         any         `json:"counter,omitempty"` // any could be an integer or string
+	Counter                         Counter     `json:"counter,omitempty"`
+}
+
+// Counter is the Seaport counter of an offerer, a decimal or 0x prefixed hex string.
+// OpenSea sends it either as a string or as a number, which is kept as is instead of losing its precision as a float64.
+type Counter string
+
+func (c *Counter) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*c = Counter(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*c = Counter(n)
+	return nil
 }
 
 func (p *Parameters) Validate() error {
@@ -297,7 +317,7 @@ func (p *Parameters) Validate() error {
 		return errx.New("conduitKey must not be empty")
 	}
 
-	if p.Counter == nil {
+	if p.Counter == "" {
 		return errx.New("counter must not be empty")
 	}
 
@@ -1,10 +1,12 @@
 package openseamodels
 
 import (
+	"encoding/json"
 	"net/url"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/xTransact/errx/v3"
+
+	"github.com/xTransact/openseaapi/chain"
 )
 
 type Nfts struct {
@@ -55,11 +57,11 @@ type GetNftsBasePayload struct {
 	*BaseQueryParams
 
 	// The unique public blockchain identifier for the contract or wallet.
-	Address common.Address `json:"address"` // required
+	Address chain.Address `json:"address"` // required
 }
 
 func (p *GetNftsBasePayload) Validate() error {
-	if !common.IsHexAddress(p.Address.String()) {
+	if p.Address.IsEmpty() {
 		return errx.New("invalid address")
 	}
 	return p.BaseQueryParams.Validate()
@@ -104,13 +106,13 @@ type NftsResponse struct {
 
 type GetNftPayload struct {
 	// The unique public blockchain identifier for the contract or wallet.
-	Address common.Address `json:"address"`
+	Address chain.Address `json:"address"`
 	// The NFT token id.
 	Identifier string `json:"identifier"`
 }
 
 func (p *GetNftPayload) Validate() error {
-	if !common.IsHexAddress(p.Address.String()) {
+	if p.Address.IsEmpty() {
 		return errx.New("invalid address")
 	}
 	if p.Identifier == "" {
@@ -122,3 +124,77 @@ func (p *GetNftPayload) Validate() error {
 type NftResponse struct {
 	Nft *Nft `json:"nft"`
 }
+
+type ValidateNftMetadataPayload struct {
+	GetNftPayload
+	// If true, the image and animation urls are fetched again instead of being read from the OpenSea cache. Default: false
+	IgnoreCachedItemUrls bool `json:"ignoreCachedItemUrls"`
+}
+
+func (p *ValidateNftMetadataPayload) ToQuery() url.Values {
+	q := make(url.Values)
+
+	if p.IgnoreCachedItemUrls {
+		q.Set("ignoreCachedItemUrls", "true")
+	}
+
+	return q
+}
+
+type ValidateNftMetadataResponse struct {
+	// The token URI the metadata was fetched from
+	TokenUri string `json:"token_uri"`
+	// The metadata as OpenSea would ingest it, nil when it could not be fetched or parsed
+	Metadata *NftMetadata `json:"metadata"`
+	// The errors met while ingesting the metadata, empty when it is valid
+	Errors []*MetadataIngestionError `json:"errors"`
+}
+
+// Valid reports whether OpenSea would ingest the metadata without error.
+func (r *ValidateNftMetadataResponse) Valid() bool {
+	return r.Metadata != nil && len(r.Errors) == 0
+}
+
+type NftMetadata struct {
+	// Name of the NFT
+	Name string `json:"name"`
+	// Description of the NFT
+	Description string `json:"description"`
+	// Link to the image associated with the NFT
+	ImageUrl string `json:"image_url"`
+	// Link to the NFT's original animation.
+	AnimationUrl string `json:"animation_url"`
+	// Link to the NFT on an external website
+	ExternalUrl string `json:"external_url"`
+	// Background color of the NFT, a six-character hexadecimal without a pre-pended #
+	BackgroundColor string `json:"background_color"`
+	// List of Trait objects parsed from the attributes of the metadata
+	Traits []*NftTrait `json:"traits"`
+}
+
+// MetadataIngestionError is an error met by OpenSea while ingesting the metadata of an NFT.
+// OpenSea reports it either as a plain message or as an object.
+type MetadataIngestionError struct {
+	// The metadata field the error is about, empty when it is about the whole metadata
+	Field string `json:"field"`
+	// The error message
+	Message string `json:"message"`
+}
+
+func (e *MetadataIngestionError) UnmarshalJSON(data []byte) error {
+	var msg string
+	if err := json.Unmarshal(data, &msg); err == nil {
+		*e = MetadataIngestionError{Message: msg}
+		return nil
+	}
+
+	type plain MetadataIngestionError
+	return json.Unmarshal(data, (*plain)(e))
+}
+
+func (e *MetadataIngestionError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/xTransact/errx/v3"
 )
 
@@ -88,6 +89,37 @@ type OfferResponse struct {
 	ProtocolAddress string `json:"protocol_address"`
 }
 
+type GetBestOfferPayload struct {
+	// Unique string to identify a collection on OpenSea.
+	// This can be found by visiting the collection on the OpenSea website and noting the last path parameter.
+	CollectionSlug string `json:"collection_slug"` // required
+	// The NFT token id.
+	Identifier string `json:"identifier"` // required
+	// Contract is the NFT contract, only used by the order page scans of openseaapi.ScanOrderPages
+	// to tell apart the NFTs of a collection spanning several contracts.
+	Contract string `json:"-"`
+	// PaymentToken is the payment token the orders are compared in, only used by the order page scans
+	// of openseaapi.ScanOrderPages, which fail when the orders are in several tokens and it is empty.
+	// The zero address stands for the native token.
+	PaymentToken string `json:"-"`
+}
+
+func (p *GetBestOfferPayload) Validate() error {
+	if p.CollectionSlug == "" {
+		return errx.New("collection_slug must not be empty")
+	}
+	if p.Identifier == "" {
+		return errx.New("identifier must not be empty")
+	}
+	if p.Contract != "" && !common.IsHexAddress(p.Contract) {
+		return errx.New("invalid contract")
+	}
+	if p.PaymentToken != "" && !common.IsHexAddress(p.PaymentToken) {
+		return errx.New("invalid payment token")
+	}
+	return nil
+}
+
 type Offers struct {
 	Offers []OfferResponse `json:"offers"`
 }
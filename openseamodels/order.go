@@ -41,3 +41,29 @@ type GetOrderResponse struct {
 	ProtocolData    *ProtocolData `json:"protocol_data"`
 	ProtocolAddress string        `json:"protocol_address"`
 }
+
+type CancelOrderPayload struct {
+	// The blockchain on which the order was placed.
+	Chain string `json:"-"`
+	// The hash of the order to cancel.
+	OrderHash string `json:"-"`
+	// The contract address of the protocol of the order.
+	ProtocolAddress string `json:"-"`
+	// An EIP-712 signature from the offerer of the order.
+	// If this is not provided, the user associated with the API Key will be checked instead, so it is required on testnets.
+	OffererSignature string `json:"offerer_signature,omitempty"`
+}
+
+func (p *CancelOrderPayload) Validate() error {
+	return (&GetOrderPayload{
+		Chain:           p.Chain,
+		OrderHash:       p.OrderHash,
+		ProtocolAddress: p.ProtocolAddress,
+	}).Validate()
+}
+
+type CancelOrderResponse struct {
+	// The time until which the last signature issued for the order remains valid, e.g. 2024-01-01T00:00:00.000000.
+	// Empty when no signature was issued: the order can no longer be fulfilled.
+	LastSignatureIssuedValidUntil string `json:"last_signature_issued_valid_until"`
+}
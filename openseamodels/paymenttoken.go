@@ -0,0 +1,26 @@
+package openseamodels
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/xTransact/openseaapi/chain"
+)
+
+type PaymentToken struct {
+	// The symbol of the payment token, e.g. WETH
+	Symbol string `json:"symbol"`
+	// The unique public blockchain identifier, address, for the payment token, the null address for native tokens
+	Address chain.Address `json:"address"`
+	// The blockchain on which the payment token is deployed
+	Chain string `json:"chain"`
+	// Image used to represent the payment token
+	Image string `json:"image"`
+	// Name of the payment token, e.g. Wrapped Ether
+	Name string `json:"name"`
+	// The number of decimals of the payment token
+	Decimals int `json:"decimals"`
+	// The price of one payment token in ETH
+	EthPrice decimal.Decimal `json:"eth_price"`
+	// The price of one payment token in USD
+	UsdPrice decimal.Decimal `json:"usd_price"`
+}
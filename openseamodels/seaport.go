@@ -0,0 +1,228 @@
+package openseamodels
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/xTransact/errx/v3"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseaconsts"
+	"github.com/xTransact/openseaapi/openseaenums"
+)
+
+// SeaportName is the name of the EIP-712 domain of every Seaport version.
+const SeaportName = "Seaport"
+
+// seaportVersions maps the Seaport contracts accepted by OpenSea to the version of their EIP-712 domain.
+var seaportVersions = map[common.Address]string{
+	openseaconsts.SeaportV15Address: "1.5",
+	openseaconsts.SeaportV16Address: "1.6",
+}
+
+// SeaportTypes are the EIP-712 types of a Seaport order, the primary type being OrderComponents.
+var SeaportTypes = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	},
+	"OrderComponents": {
+		{Name: "offerer", Type: "address"},
+		{Name: "zone", Type: "address"},
+		{Name: "offer", Type: "OfferItem[]"},
+		{Name: "consideration", Type: "ConsiderationItem[]"},
+		{Name: "orderType", Type: "uint8"},
+		{Name: "startTime", Type: "uint256"},
+		{Name: "endTime", Type: "uint256"},
+		{Name: "zoneHash", Type: "bytes32"},
+		{Name: "salt", Type: "uint256"},
+		{Name: "conduitKey", Type: "bytes32"},
+		{Name: "counter", Type: "uint256"},
+	},
+	"OfferItem": {
+		{Name: "itemType", Type: "uint8"},
+		{Name: "token", Type: "address"},
+		{Name: "identifierOrCriteria", Type: "uint256"},
+		{Name: "startAmount", Type: "uint256"},
+		{Name: "endAmount", Type: "uint256"},
+	},
+	"ConsiderationItem": {
+		{Name: "itemType", Type: "uint8"},
+		{Name: "token", Type: "address"},
+		{Name: "identifierOrCriteria", Type: "uint256"},
+		{Name: "startAmount", Type: "uint256"},
+		{Name: "endAmount", Type: "uint256"},
+		{Name: "recipient", Type: "address"},
+	},
+}
+
+// SeaportDomain returns the EIP-712 domain of the Seaport contract deployed at protocolAddress on the chain,
+// its version depending on the contract, e.g. 1.6 for openseaconsts.SeaportV16Address.
+func SeaportDomain(ch chain.Chain, protocolAddress string) (apitypes.TypedDataDomain, error) {
+	if ch.IsSolana() || ch.ChainId() <= 0 {
+		return apitypes.TypedDataDomain{}, errx.Errorf("seaport is not deployed on %s", ch.Name())
+	}
+	if !common.IsHexAddress(protocolAddress) {
+		return apitypes.TypedDataDomain{}, errx.Errorf("invalid protocol_address: %s", protocolAddress)
+	}
+	contract := common.HexToAddress(protocolAddress)
+	version, ok := seaportVersions[contract]
+	if !ok {
+		return apitypes.TypedDataDomain{}, errx.Errorf("unsupported seaport contract: %s", contract.Hex())
+	}
+
+	return apitypes.TypedDataDomain{
+		Name:              SeaportName,
+		Version:           version,
+		ChainId:           math.NewHexOrDecimal256(int64(ch.ChainId())),
+		VerifyingContract: contract.Hex(),
+	}, nil
+}
+
+// TypedData returns the EIP-712 typed data of the order signed by the offerer,
+// for the Seaport contract deployed at protocolAddress on the chain.
+// The signed OrderComponents are the parameters with the counter in place of totalOriginalConsiderationItems.
+func (p *Parameters) TypedData(ch chain.Chain, protocolAddress string) (*apitypes.TypedData, error) {
+	domain, err := SeaportDomain(ch, protocolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if !common.IsHexAddress(p.Offerer) {
+		return nil, errx.Errorf("invalid offerer: %s", p.Offerer)
+	}
+	if !common.IsHexAddress(p.Zone) {
+		return nil, errx.Errorf("invalid zone: %s", p.Zone)
+	}
+	zoneHash, err := bytes32("zoneHash", p.ZoneHash)
+	if err != nil {
+		return nil, err
+	}
+	conduitKey, err := bytes32("conduitKey", p.ConduitKey)
+	if err != nil {
+		return nil, err
+	}
+
+	offer := make([]any, 0, len(p.Offer))
+	for i, o := range p.Offer {
+		if o == nil || o.BaseOfferAndConsideration == nil {
+			return nil, errx.Errorf("offer[%d] must not be nil", i)
+		}
+		item, err := o.BaseOfferAndConsideration.typedData(fmt.Sprintf("offer[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		offer = append(offer, item)
+	}
+
+	consideration := make([]any, 0, len(p.Consideration))
+	for i, c := range p.Consideration {
+		if c == nil || c.BaseOfferAndConsideration == nil {
+			return nil, errx.Errorf("consideration[%d] must not be nil", i)
+		}
+		item, err := c.BaseOfferAndConsideration.typedData(fmt.Sprintf("consideration[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		item["recipient"] = c.Recipient.Hex()
+		consideration = append(consideration, item)
+	}
+
+	message := apitypes.TypedDataMessage{
+		"offerer":       common.HexToAddress(p.Offerer).Hex(),
+		"zone":          common.HexToAddress(p.Zone).Hex(),
+		"offer":         offer,
+		"consideration": consideration,
+		"orderType":     big.NewInt(int64(p.OrderType)).String(),
+		"zoneHash":      zoneHash,
+		"conduitKey":    conduitKey,
+	}
+	for name, v := range map[string]string{
+		"startTime": string(p.StartTime),
+		"endTime":   string(p.EndTime),
+		"salt":      p.Salt,
+		"counter":   string(p.Counter),
+	} {
+		n, err := uint256(name, v)
+		if err != nil {
+			return nil, err
+		}
+		message[name] = n
+	}
+
+	return &apitypes.TypedData{
+		Types:       SeaportTypes,
+		PrimaryType: "OrderComponents",
+		Domain:      domain,
+		Message:     message,
+	}, nil
+}
+
+// Hash returns the EIP-712 digest of the order signed by the offerer, see TypedData.
+func (p *Parameters) Hash(ch chain.Chain, protocolAddress string) (common.Hash, error) {
+	typedData, err := p.TypedData(ch, protocolAddress)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash, _, err := apitypes.TypedDataAndHash(*typedData)
+	if err != nil {
+		return common.Hash{}, errx.Wrap(err, "hash typed data")
+	}
+	return common.BytesToHash(hash), nil
+}
+
+func (b *BaseOfferAndConsideration) typedData(name string) (map[string]any, error) {
+	if !openseaenums.ValidateItemType(int(b.ItemType)) {
+		return nil, errx.Errorf("invalid %s.itemType", name)
+	}
+
+	item := map[string]any{
+		"itemType": big.NewInt(int64(b.ItemType)).String(),
+		"token":    b.Token.Hex(),
+	}
+	for field, v := range map[string]json.Number{
+		"identifierOrCriteria": b.IdentifierOrCriteria,
+		"startAmount":          b.StartAmount,
+		"endAmount":            b.EndAmount,
+	} {
+		n, err := uint256(name+"."+field, string(v))
+		if err != nil {
+			return nil, err
+		}
+		item[field] = n
+	}
+	return item, nil
+}
+
+// uint256 formats a number of the parameters, given as a decimal or 0x prefixed hex string.
+// The numbers are never decoded as float64, which would lose the precision above 2^53.
+func uint256(name, s string) (string, error) {
+	n, ok := parseUint256(s)
+	if !ok || n.Sign() < 0 || n.BitLen() > 256 {
+		return "", errx.Errorf("invalid %s: %q", name, s)
+	}
+	return n.String(), nil
+}
+
+// parseUint256 parses a decimal or 0x prefixed hex string.
+func parseUint256(s string) (*big.Int, bool) {
+	if len(s) > 2 && (s[:2] == "0x" || s[:2] == "0X") {
+		return new(big.Int).SetString(s[2:], 16)
+	}
+	return new(big.Int).SetString(s, 10)
+}
+
+func bytes32(name, s string) (string, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) != common.HashLength {
+		return "", errx.Errorf("invalid %s: %s", name, s)
+	}
+	return hexutil.Encode(b), nil
+}
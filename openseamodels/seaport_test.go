@@ -0,0 +1,197 @@
+package openseamodels
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseaconsts"
+	"github.com/xTransact/openseaapi/openseaenums"
+)
+
+// testParameters is a listing of an ERC721 for 1 ETH, with a 2.5% fee to OpenSea.
+func testParameters() *Parameters {
+	return &Parameters{
+		Offerer: "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266",
+		Offer: []*Offer{{BaseOfferAndConsideration: &BaseOfferAndConsideration{
+			ItemType:             openseaenums.ItemTypeERC721,
+			Token:                common.HexToAddress("0xED5AF388653567Af2F388E6224dC7C4b3241C544"),
+			IdentifierOrCriteria: "7",
+			StartAmount:          "1",
+			EndAmount:            "1",
+		}}},
+		Consideration: []*Consideration{
+			{
+				BaseOfferAndConsideration: &BaseOfferAndConsideration{
+					ItemType:             openseaenums.ItemTypeNative,
+					IdentifierOrCriteria: "0",
+					StartAmount:          "975000000000000000",
+					EndAmount:            "975000000000000000",
+				},
+				Recipient: common.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"),
+			},
+			{
+				BaseOfferAndConsideration: &BaseOfferAndConsideration{
+					ItemType:             openseaenums.ItemTypeNative,
+					IdentifierOrCriteria: "0",
+					StartAmount:          "25000000000000000",
+					EndAmount:            "25000000000000000",
+				},
+				Recipient: common.HexToAddress("0x0000a26b00c1F0DF003000390027140000fAa719"),
+			},
+		},
+		StartTime:                       "1700641471",
+		EndTime:                         "1700727871",
+		OrderType:                       openseaenums.OrderType(0),
+		Zone:                            "0x0000000000000000000000000000000000000000",
+		ZoneHash:                        "0x0000000000000000000000000000000000000000000000000000000000000000",
+		Salt:                            "0x360c6ebe0000000000000000000000000000000000000000d2f5ab6a8b5b8dbb",
+		ConduitKey:                      "0x0000007b02230091a7ed01230072f7006a004d60a8d4e71d599b8104250f0000",
+		TotalOriginalConsiderationItems: "2",
+		Counter:                         "0",
+	}
+}
+
+func TestSeaportTypeHashes(t *testing.T) {
+	// 与 Seaport 合约中的常量一致
+	typedData := apitypes.TypedData{Types: SeaportTypes}
+	assert.Equal(t, "0xfa445660b7e21515a59617fcd68910b487aa5808b8abda3d78bc85df364b2c2f",
+		typedData.TypeHash("OrderComponents").String())
+	assert.Equal(t, "0xa66999307ad1bb4fde44d13a5d710bd7718e0c87c1eef68a571629fbf5b93d02",
+		typedData.TypeHash("OfferItem").String())
+	assert.Equal(t, "0x42d81c6929ffdc4eb27a0808e40e82516ad42296c166065de7f812492304ff6e",
+		typedData.TypeHash("ConsiderationItem").String())
+	assert.Equal(t, "0x8b73c3c69bb8fe3d512ecc4cf759cc79239f7b179b0ffacaa9a75d522b39400f",
+		typedData.TypeHash("EIP712Domain").String())
+}
+
+func TestSeaportDomain(t *testing.T) {
+	domain, err := SeaportDomain(chain.Ethereum, "0x0000000000000068f116a894984e2db1123eb395")
+	require.NoError(t, err)
+	assert.Equal(t, "Seaport", domain.Name)
+	assert.Equal(t, "1.6", domain.Version)
+	assert.Equal(t, openseaconsts.SeaportV16Address.Hex(), domain.VerifyingContract)
+
+	domain, err = SeaportDomain(chain.Matic, openseaconsts.SeaportV15Address.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "1.5", domain.Version)
+	assert.Equal(t, big.NewInt(137), (*big.Int)(domain.ChainId))
+
+	_, err = SeaportDomain(chain.Ethereum, "0x00000000006c3852cbEf3e08E8dF289169EdE581")
+	assert.Error(t, err)
+	_, err = SeaportDomain(chain.Solana, openseaconsts.SeaportV16Address.Hex())
+	assert.Error(t, err)
+}
+
+func TestParametersHash(t *testing.T) {
+	p := testParameters()
+	hash, err := p.Hash(chain.Ethereum, openseaconsts.SeaportV16Address.Hex())
+	require.NoError(t, err)
+
+	// 按 EIP-712 手工编码，与 apitypes 的结果交叉验证
+	word := func(v any) []byte {
+		switch v := v.(type) {
+		case int:
+			return common.LeftPadBytes(big.NewInt(int64(v)).Bytes(), 32)
+		case string:
+			n, ok := new(big.Int).SetString(v, 0)
+			require.True(t, ok, v)
+			return common.LeftPadBytes(n.Bytes(), 32)
+		case common.Address:
+			return common.LeftPadBytes(v.Bytes(), 32)
+		case common.Hash:
+			return v.Bytes()
+		}
+		panic(v)
+	}
+	hashOf := func(words ...[]byte) common.Hash {
+		return crypto.Keccak256Hash(words...)
+	}
+
+	offerItemType := common.HexToHash("0xa66999307ad1bb4fde44d13a5d710bd7718e0c87c1eef68a571629fbf5b93d02")
+	considerationItemType := common.HexToHash("0x42d81c6929ffdc4eb27a0808e40e82516ad42296c166065de7f812492304ff6e")
+	orderType := common.HexToHash("0xfa445660b7e21515a59617fcd68910b487aa5808b8abda3d78bc85df364b2c2f")
+	domainType := common.HexToHash("0x8b73c3c69bb8fe3d512ecc4cf759cc79239f7b179b0ffacaa9a75d522b39400f")
+
+	nft := common.HexToAddress("0xED5AF388653567Af2F388E6224dC7C4b3241C544")
+	offerer := common.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	fee := common.HexToAddress("0x0000a26b00c1F0DF003000390027140000fAa719")
+
+	offer := hashOf(hashOf(word(offerItemType), word(2), word(nft), word(7), word(1), word(1)).Bytes())
+	consideration := hashOf(
+		hashOf(word(considerationItemType), word(0), word(common.Address{}), word(0),
+			word("975000000000000000"), word("975000000000000000"), word(offerer)).Bytes(),
+		hashOf(word(considerationItemType), word(0), word(common.Address{}), word(0),
+			word("25000000000000000"), word("25000000000000000"), word(fee)).Bytes(),
+	)
+	order := hashOf(word(orderType), word(offerer), word(common.Address{}), offer.Bytes(), consideration.Bytes(),
+		word(0), word(1700641471), word(1700727871), word(common.Hash{}),
+		word("0x360c6ebe0000000000000000000000000000000000000000d2f5ab6a8b5b8dbb"),
+		word(common.HexToHash("0x0000007b02230091a7ed01230072f7006a004d60a8d4e71d599b8104250f0000")), word(0))
+	domain := hashOf(word(domainType), crypto.Keccak256([]byte("Seaport")), crypto.Keccak256([]byte("1.6")),
+		word(1), word(openseaconsts.SeaportV16Address))
+	expected := hashOf([]byte("\x19\x01"), domain.Bytes(), order.Bytes())
+
+	assert.Equal(t, expected, hash)
+
+	// 数值字段可为十进制或十六进制
+	p.Counter = "0x0"
+	p.StartTime = "0x655dbabf"
+	same, err := p.Hash(chain.Ethereum, openseaconsts.SeaportV16Address.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, hash, same)
+
+	p.Counter = "1"
+	other, err := p.Hash(chain.Ethereum, openseaconsts.SeaportV16Address.Hex())
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	// 不同的链签名的数据不同
+	other, err = p.Hash(chain.Matic, openseaconsts.SeaportV16Address.Hex())
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+}
+
+func TestParametersCounterPrecision(t *testing.T) {
+	// 超过 2^53 的 counter 解码后不丢失精度
+	var p Parameters
+	require.NoError(t, json.Unmarshal([]byte(`{"counter":9007199254740993}`), &p))
+	assert.Equal(t, Counter("9007199254740993"), p.Counter)
+	require.NoError(t, json.Unmarshal([]byte(`{"counter":"9007199254740993"}`), &p))
+	assert.Equal(t, Counter("9007199254740993"), p.Counter)
+	assert.Error(t, json.Unmarshal([]byte(`{"counter":true}`), &p))
+
+	q := testParameters()
+	q.Counter = p.Counter
+	data, err := q.TypedData(chain.Ethereum, openseaconsts.SeaportV16Address.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "9007199254740993", data.Message["counter"])
+}
+
+func TestParametersTypedDataInvalid(t *testing.T) {
+	for name, mutate := range map[string]func(p *Parameters){
+		"offerer":     func(p *Parameters) { p.Offerer = "" },
+		"zone hash":   func(p *Parameters) { p.ZoneHash = "0x00" },
+		"counter":     func(p *Parameters) { p.Counter = "" },
+		"negative":    func(p *Parameters) { p.Counter = "-1" },
+		"fraction":    func(p *Parameters) { p.Counter = "1.5" },
+		"exponent":    func(p *Parameters) { p.Counter = "1e3" },
+		"salt":        func(p *Parameters) { p.Salt = "salt" },
+		"amount":      func(p *Parameters) { p.Offer[0].StartAmount = "" },
+		"item type":   func(p *Parameters) { p.Offer[0].ItemType = 6 },
+		"nil offer":   func(p *Parameters) { p.Offer[0] = nil },
+		"nil receive": func(p *Parameters) { p.Consideration[1] = nil },
+	} {
+		p := testParameters()
+		mutate(p)
+		_, err := p.TypedData(chain.Ethereum, openseaconsts.SeaportV16Address.Hex())
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,192 @@
+package openseamodels
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/xTransact/openseaapi/openseaenums"
+)
+
+// StreamEvent is the envelope of the events pushed by the OpenSea Stream API,
+// Payload is decoded according to EventType, e.g. into an ItemSoldEvent for item_sold.
+type StreamEvent struct {
+	EventType openseaenums.StreamEventType `json:"event_type"`
+	// The time the event was sent, e.g. 2024-01-01T00:00:00.000000+00:00
+	SentAt  string          `json:"sent_at"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type StreamCollection struct {
+	// Collection slug. A unique string to identify a collection on OpenSea
+	Slug string `json:"slug"`
+}
+
+type StreamChain struct {
+	// The name of the blockchain, e.g. ethereum
+	Name string `json:"name"`
+}
+
+type StreamAccount struct {
+	// The unique public blockchain identifier for the wallet
+	Address string `json:"address"`
+}
+
+type StreamTransaction struct {
+	// The hash of the transaction
+	Hash string `json:"hash"`
+	// The time the transaction was mined
+	Timestamp string `json:"timestamp"`
+}
+
+type StreamItemMetadata struct {
+	// Name of the NFT
+	Name string `json:"name"`
+	// Description of the NFT
+	Description string `json:"description"`
+	// Link to the image associated with the NFT
+	ImageUrl string `json:"image_url"`
+	// Link to the NFT's original animation.
+	AnimationUrl string `json:"animation_url"`
+	// Link to the offchain metadata store
+	MetadataUrl string `json:"metadata_url"`
+	// Background color of the NFT
+	BackgroundColor string `json:"background_color"`
+	// List of Trait objects, only sent by item_metadata_updated
+	Traits []*NftTrait `json:"traits"`
+}
+
+type StreamItem struct {
+	// The NFT of the event as {chain}/{contract}/{identifier}, see NftID
+	NftId string `json:"nft_id"`
+	// Link to the NFT on OpenSea
+	Permalink string              `json:"permalink"`
+	Metadata  *StreamItemMetadata `json:"metadata"`
+	Chain     *StreamChain        `json:"chain"`
+}
+
+// NftID splits the NftId of the item into its chain, contract address and token identifier.
+func (i *StreamItem) NftID() (chain, contract, identifier string, ok bool) {
+	parts := strings.Split(i.NftId, "/")
+	if len(parts) != 3 {
+		return "", "", "", false
+	}
+	return parts[0], parts[1], parts[2], true
+}
+
+// StreamEventBase holds the fields sent with every event.
+type StreamEventBase struct {
+	// The time the event happened
+	EventTimestamp string           `json:"event_timestamp"`
+	Collection     StreamCollection `json:"collection"`
+}
+
+// StreamItemEventBase holds the fields sent with every event about a single NFT.
+type StreamItemEventBase struct {
+	StreamEventBase
+	Item StreamItem `json:"item"`
+}
+
+type ItemListedEvent struct {
+	StreamItemEventBase
+	// The listing price in the smallest unit of the payment token, e.g. wei
+	BasePrice       decimal.Decimal `json:"base_price"`
+	ExpirationDate  string          `json:"expiration_date"`
+	IsPrivate       bool            `json:"is_private"`
+	ListingDate     string          `json:"listing_date"`
+	ListingType     string          `json:"listing_type"`
+	Maker           *StreamAccount  `json:"maker"`
+	Taker           *StreamAccount  `json:"taker"`
+	PaymentToken    *PaymentToken   `json:"payment_token"`
+	Quantity        int             `json:"quantity"`
+	OrderHash       string          `json:"order_hash"`
+	ProtocolData    *ProtocolData   `json:"protocol_data"`
+	ProtocolAddress string          `json:"protocol_address"`
+}
+
+type ItemSoldEvent struct {
+	StreamItemEventBase
+	ClosingDate  string         `json:"closing_date"`
+	IsPrivate    bool           `json:"is_private"`
+	ListingType  string         `json:"listing_type"`
+	Maker        *StreamAccount `json:"maker"`
+	Taker        *StreamAccount `json:"taker"`
+	PaymentToken *PaymentToken  `json:"payment_token"`
+	Quantity     int            `json:"quantity"`
+	// The sale price in the smallest unit of the payment token, e.g. wei
+	SalePrice       decimal.Decimal    `json:"sale_price"`
+	Transaction     *StreamTransaction `json:"transaction"`
+	OrderHash       string             `json:"order_hash"`
+	ProtocolAddress string             `json:"protocol_address"`
+}
+
+type ItemTransferredEvent struct {
+	StreamItemEventBase
+	FromAccount *StreamAccount     `json:"from_account"`
+	ToAccount   *StreamAccount     `json:"to_account"`
+	Quantity    int                `json:"quantity"`
+	Transaction *StreamTransaction `json:"transaction"`
+}
+
+type ItemMetadataUpdatedEvent struct {
+	StreamItemEventBase
+}
+
+type ItemCancelledEvent struct {
+	StreamItemEventBase
+	ListingType     string             `json:"listing_type"`
+	PaymentToken    *PaymentToken      `json:"payment_token"`
+	Quantity        int                `json:"quantity"`
+	Transaction     *StreamTransaction `json:"transaction"`
+	OrderHash       string             `json:"order_hash"`
+	ProtocolAddress string             `json:"protocol_address"`
+}
+
+type ItemReceivedOfferEvent struct {
+	StreamItemEventBase
+	// The offer price in the smallest unit of the payment token, e.g. wei
+	BasePrice       decimal.Decimal `json:"base_price"`
+	CreatedDate     string          `json:"created_date"`
+	ExpirationDate  string          `json:"expiration_date"`
+	Maker           *StreamAccount  `json:"maker"`
+	Taker           *StreamAccount  `json:"taker"`
+	PaymentToken    *PaymentToken   `json:"payment_token"`
+	Quantity        int             `json:"quantity"`
+	OrderHash       string          `json:"order_hash"`
+	ProtocolData    *ProtocolData   `json:"protocol_data"`
+	ProtocolAddress string          `json:"protocol_address"`
+}
+
+// ItemReceivedBidEvent is an offer on an NFT listed as an auction, it has the fields of ItemReceivedOfferEvent.
+type ItemReceivedBidEvent ItemReceivedOfferEvent
+
+type CollectionOfferEvent struct {
+	StreamEventBase
+	// The offer price in the smallest unit of the payment token, e.g. wei
+	BasePrice             decimal.Decimal   `json:"base_price"`
+	CollectionCriteria    *StreamCollection `json:"collection_criteria"`
+	AssetContractCriteria *StreamAccount    `json:"asset_contract_criteria"`
+	CreatedDate           string            `json:"created_date"`
+	ExpirationDate        string            `json:"expiration_date"`
+	Maker                 *StreamAccount    `json:"maker"`
+	Taker                 *StreamAccount    `json:"taker"`
+	PaymentToken          *PaymentToken     `json:"payment_token"`
+	// The number of NFTs wanted by the offer
+	Quantity        int           `json:"quantity"`
+	OrderHash       string        `json:"order_hash"`
+	ProtocolData    *ProtocolData `json:"protocol_data"`
+	ProtocolAddress string        `json:"protocol_address"`
+}
+
+type StreamTraitCriteria struct {
+	// The name of the trait (e.g. 'Background')
+	TraitType string `json:"trait_type"`
+	// The value of the trait (e.g. 'Red')
+	TraitName string `json:"trait_name"`
+}
+
+type TraitOfferEvent struct {
+	CollectionOfferEvent
+	TraitCriteria *StreamTraitCriteria `json:"trait_criteria"`
+}
@@ -0,0 +1,503 @@
+package openseastream
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"log/slog"
+	"net"
+	neturl "net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xTransact/errx/v3"
+	"golang.org/x/net/websocket"
+
+	"github.com/xTransact/openseaapi/openseaconsts"
+	"github.com/xTransact/openseaapi/openseaenums"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+// AllCollections subscribes to the events of every collection.
+const AllCollections = "*"
+
+// Phoenix channel protocol, see https://hexdocs.pm/phoenix/writing_a_channels_client.html
+const (
+	phoenixTopic    = "phoenix"
+	collectionTopic = "collection:"
+
+	eventJoin      = "phx_join"
+	eventLeave     = "phx_leave"
+	eventReply     = "phx_reply"
+	eventError     = "phx_error"
+	eventClose     = "phx_close"
+	eventHeartbeat = "heartbeat"
+)
+
+// dialTimeout bounds the opening of a connection, websocket handshake included.
+const dialTimeout = 10 * time.Second
+
+// message is a Phoenix channel message, Ref is null for the events pushed by the server.
+type message struct {
+	Topic   string          `json:"topic"`
+	Event   string          `json:"event"`
+	Payload json.RawMessage `json:"payload"`
+	Ref     *string         `json:"ref"`
+}
+
+type reply struct {
+	Status   string          `json:"status"`
+	Response json.RawMessage `json:"response"`
+}
+
+// Event is an event pushed by the Stream API, decode its payload with Decode or subscribe with the typed On* methods.
+type Event struct {
+	Type openseaenums.StreamEventType
+	// Collection is the slug of the collection of the event.
+	Collection string
+	// SentAt is the time the event was sent, e.g. 2024-01-01T00:00:00.000000+00:00
+	SentAt string
+	// Payload is the raw payload of the event.
+	Payload json.RawMessage
+}
+
+// Decode decodes the payload of the event into its openseamodels type, e.g. *openseamodels.ItemSoldEvent for item_sold.
+func (e *Event) Decode() (any, error) {
+	var v any
+	switch e.Type {
+	case openseaenums.StreamEventItemListed:
+		v = new(openseamodels.ItemListedEvent)
+	case openseaenums.StreamEventItemSold:
+		v = new(openseamodels.ItemSoldEvent)
+	case openseaenums.StreamEventItemTransferred:
+		v = new(openseamodels.ItemTransferredEvent)
+	case openseaenums.StreamEventItemMetadataUpdated:
+		v = new(openseamodels.ItemMetadataUpdatedEvent)
+	case openseaenums.StreamEventItemCancelled:
+		v = new(openseamodels.ItemCancelledEvent)
+	case openseaenums.StreamEventItemReceivedOffer:
+		v = new(openseamodels.ItemReceivedOfferEvent)
+	case openseaenums.StreamEventItemReceivedBid:
+		v = new(openseamodels.ItemReceivedBidEvent)
+	case openseaenums.StreamEventCollectionOffer:
+		v = new(openseamodels.CollectionOfferEvent)
+	case openseaenums.StreamEventTraitOffer:
+		v = new(openseamodels.TraitOfferEvent)
+	default:
+		return nil, errx.Errorf("unknown event type: %s", e.Type)
+	}
+
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return nil, errx.Wrapf(err, "unmarshal %s payload", e.Type)
+	}
+	return v, nil
+}
+
+// session is the state of a single connection.
+type session struct {
+	conn *websocket.Conn
+	stop chan struct{}
+	// pending is the ref of the heartbeat waiting for its reply, 0 if none
+	pending atomic.Uint64
+	// rejoins are the backoffs of the channels closed by the server, only used by the receive loop
+	rejoins map[string]*rejoin
+}
+
+// rejoin is the backoff of the rejoins of a channel.
+type rejoin struct {
+	delay    time.Duration
+	joinedAt time.Time
+}
+
+type subscription struct {
+	// types are the event types of the subscription, all of them when empty
+	types map[openseaenums.StreamEventType]bool
+	fn    func(*Event)
+}
+
+// Client is a client of the OpenSea Stream API, which pushes the events of the collections over a WebSocket
+// using the Phoenix channel protocol. Subscribe to the collections, then Run the client:
+//
+//	cli := openseastream.NewClient(openseastream.WithApiKey(apiKey))
+//	cli.OnItemSold("azuki", func(e *openseamodels.ItemSoldEvent) {
+//		...
+//	})
+//	err := cli.Run(ctx)
+//
+// Subscriptions may be added and removed at any time, they survive the reconnections.
+// Handlers are called one at a time from the connection goroutine and should return quickly.
+type Client struct {
+	config *options
+	url    string
+	ref    atomic.Uint64
+
+	mu     sync.Mutex
+	subs   map[string]map[uint64]*subscription // topic => id => subscription
+	nextID uint64
+	conn   *websocket.Conn
+}
+
+func NewClient(opts ...OptionFn) *Client {
+	o := new(options)
+	for _, apply := range opts {
+		apply(o)
+	}
+	if o.heartbeatInterval <= 0 {
+		o.heartbeatInterval = DefaultHeartbeatInterval
+	}
+	if o.minReconnectDelay <= 0 {
+		o.minReconnectDelay = DefaultMinReconnectDelay
+	}
+	if o.maxReconnectDelay < o.minReconnectDelay {
+		o.maxReconnectDelay = max(DefaultMaxReconnectDelay, o.minReconnectDelay)
+	}
+
+	url := o.url
+	if url == "" {
+		url = StreamUrlProd
+		if o.testnets {
+			url = StreamUrlTest
+		}
+	}
+	if o.apiKey != "" {
+		if u, err := neturl.Parse(url); err == nil {
+			q := u.Query()
+			q.Set("token", o.apiKey)
+			u.RawQuery = q.Encode()
+			url = u.String()
+		}
+	}
+
+	return &Client{
+		config: o,
+		url:    url,
+		subs:   make(map[string]map[uint64]*subscription),
+	}
+}
+
+func (c *Client) logger() *slog.Logger {
+	if c.config.logger != nil {
+		return c.config.logger
+	}
+	return slog.Default()
+}
+
+// Subscribe calls fn for the events of the collection, or of every collection with AllCollections,
+// restricted to the given event types if any. It returns a function removing the subscription.
+func (c *Client) Subscribe(slug string, fn func(*Event), types ...openseaenums.StreamEventType) (unsubscribe func()) {
+	topic := collectionTopic + slug
+	sub := &subscription{fn: fn}
+	if len(types) > 0 {
+		sub.types = make(map[openseaenums.StreamEventType]bool, len(types))
+		for _, t := range types {
+			sub.types[t] = true
+		}
+	}
+
+	c.mu.Lock()
+	c.nextID++
+	id := c.nextID
+	subs, joined := c.subs[topic]
+	if !joined {
+		subs = make(map[uint64]*subscription)
+		c.subs[topic] = subs
+	}
+	subs[id] = sub
+	conn := c.conn
+	c.mu.Unlock()
+
+	// 已连接时立即加入新的 topic，否则在连接建立时统一加入
+	if !joined && conn != nil {
+		c.send(conn, topic, eventJoin)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			delete(c.subs[topic], id)
+			left := len(c.subs[topic]) == 0
+			if left {
+				delete(c.subs, topic)
+			}
+			conn := c.conn
+			c.mu.Unlock()
+
+			if left && conn != nil {
+				c.send(conn, topic, eventLeave)
+			}
+		})
+	}
+}
+
+// Run connects to the Stream API and dispatches the events to the subscriptions until ctx is done,
+// reconnecting and resubscribing with an exponential backoff whenever the connection is lost.
+// It returns the error of ctx. A client must not be run more than once at a time.
+func (c *Client) Run(ctx context.Context) error {
+	delay := c.config.minReconnectDelay
+	for {
+		connected, err := c.serve(ctx)
+		if ctx.Err() != nil {
+			return errx.WithStack(ctx.Err())
+		}
+		if connected {
+			delay = c.config.minReconnectDelay
+		}
+
+		c.logger().Warn("opensea stream disconnected", "error", err, "retry_in", delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return errx.WithStack(ctx.Err())
+		}
+		delay = min(2*delay, c.config.maxReconnectDelay)
+	}
+}
+
+// serve runs a single connection until it is lost, connected is false when it could not be opened.
+func (c *Client) serve(ctx context.Context) (connected bool, err error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return false, errx.Wrap(err, "dial")
+	}
+
+	s := &session{conn: conn, stop: make(chan struct{}), rejoins: make(map[string]*rejoin)}
+	var wg sync.WaitGroup
+	defer func() {
+		c.mu.Lock()
+		c.conn = nil
+		c.mu.Unlock()
+
+		close(s.stop)
+		_ = conn.Close()
+		wg.Wait()
+	}()
+
+	c.mu.Lock()
+	c.conn = conn
+	topics := make([]string, 0, len(c.subs))
+	for topic := range c.subs {
+		topics = append(topics, topic)
+	}
+	c.mu.Unlock()
+
+	for _, topic := range topics {
+		c.send(conn, topic, eventJoin)
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-s.stop:
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		c.heartbeat(s)
+	}()
+
+	for {
+		var msg message
+		if err = websocket.JSON.Receive(conn, &msg); err != nil {
+			return true, errx.Wrap(err, "receive")
+		}
+		c.handle(s, &msg)
+	}
+}
+
+// dial opens a connection until ctx is done, bounded by dialTimeout.
+func (c *Client) dial(ctx context.Context) (*websocket.Conn, error) {
+	config, err := websocket.NewConfig(c.url, openseaconsts.BaseUrlProd)
+	if err != nil {
+		return nil, errx.Wrap(err, "websocket config")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	host, port := config.Location.Hostname(), config.Location.Port()
+	if port == "" {
+		port = "80"
+		if config.Location.Scheme == "wss" {
+			port = "443"
+		}
+	}
+	raw, err := new(net.Dialer).DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+	if config.Location.Scheme == "wss" {
+		tlsConn := tls.Client(raw, &tls.Config{ServerName: host})
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			_ = raw.Close()
+			return nil, errx.Wrap(err, "tls handshake")
+		}
+		raw = tlsConn
+	}
+
+	// websocket 握手不感知 ctx，ctx 结束时关闭连接使其返回
+	stop := context.AfterFunc(ctx, func() {
+		_ = raw.Close()
+	})
+	conn, err := websocket.NewClient(config, raw)
+	if !stop() {
+		return nil, errx.WithStack(context.Cause(ctx))
+	}
+	if err != nil {
+		_ = raw.Close()
+		return nil, errx.Wrap(err, "websocket handshake")
+	}
+	return conn, nil
+}
+
+// heartbeat sends the heartbeats, closing the connection when one is not answered before the next.
+func (c *Client) heartbeat(s *session) {
+	ticker := time.NewTicker(c.config.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if s.pending.Load() != 0 {
+			c.logger().Warn("opensea stream heartbeat timeout")
+			_ = s.conn.Close()
+			return
+		}
+		ref := c.ref.Add(1)
+		s.pending.Store(ref)
+		if err := c.sendRef(s.conn, phoenixTopic, eventHeartbeat, ref); err != nil {
+			_ = s.conn.Close()
+			return
+		}
+	}
+}
+
+func (c *Client) handle(s *session, msg *message) {
+	switch msg.Event {
+	case eventReply:
+		if msg.Topic == phoenixTopic {
+			if msg.Ref != nil {
+				if ref, err := strconv.ParseUint(*msg.Ref, 10, 64); err == nil {
+					s.pending.CompareAndSwap(ref, 0)
+				}
+			}
+			return
+		}
+
+		var r reply
+		if err := json.Unmarshal(msg.Payload, &r); err == nil && r.Status != "ok" {
+			c.logger().Error("opensea stream join failed",
+				"topic", msg.Topic, "status", r.Status, "response", string(r.Response))
+		} else if err == nil {
+			c.rejoinOf(s, msg.Topic).joinedAt = time.Now()
+		}
+	case eventError, eventClose:
+		c.mu.Lock()
+		_, subscribed := c.subs[msg.Topic]
+		c.mu.Unlock()
+
+		// phx_close 也会在主动退订后收到，仅重新加入仍有订阅的 topic
+		if subscribed {
+			c.scheduleRejoin(s, msg)
+		}
+	default:
+		c.dispatch(msg)
+	}
+}
+
+func (c *Client) rejoinOf(s *session, topic string) *rejoin {
+	r, ok := s.rejoins[topic]
+	if !ok {
+		r = new(rejoin)
+		s.rejoins[topic] = r
+	}
+	return r
+}
+
+// scheduleRejoin rejoins a channel closed by the server with an exponential backoff, like the reconnections.
+// The backoff starts over once the channel stayed joined longer than the maximum delay.
+func (c *Client) scheduleRejoin(s *session, msg *message) {
+	r := c.rejoinOf(s, msg.Topic)
+	minDelay, maxDelay := c.config.minReconnectDelay, c.config.maxReconnectDelay
+	if r.delay == 0 || (!r.joinedAt.IsZero() && time.Since(r.joinedAt) > maxDelay) {
+		r.delay = minDelay
+	} else {
+		r.delay = min(2*r.delay, maxDelay)
+	}
+
+	c.logger().Warn("opensea stream channel closed, rejoining",
+		"topic", msg.Topic, "event", msg.Event, "retry_in", r.delay)
+	topic := msg.Topic
+	time.AfterFunc(r.delay, func() {
+		c.mu.Lock()
+		_, subscribed := c.subs[topic]
+		current := c.conn == s.conn
+		c.mu.Unlock()
+
+		// 期间退订或断线的不再加入，重连时会统一加入
+		if subscribed && current {
+			c.send(s.conn, topic, eventJoin)
+		}
+	})
+}
+
+func (c *Client) dispatch(msg *message) {
+	var envelope openseamodels.StreamEvent
+	if err := json.Unmarshal(msg.Payload, &envelope); err != nil {
+		c.logger().Error("opensea stream unmarshal event", "topic", msg.Topic, "event", msg.Event, "error", err)
+		return
+	}
+
+	e := &Event{
+		Type:    envelope.EventType,
+		SentAt:  envelope.SentAt,
+		Payload: envelope.Payload,
+	}
+	if e.Type == "" {
+		e.Type = openseaenums.StreamEventType(msg.Event)
+	}
+	var base openseamodels.StreamEventBase
+	if err := json.Unmarshal(e.Payload, &base); err == nil {
+		e.Collection = base.Collection.Slug
+	}
+
+	c.mu.Lock()
+	fns := make([]func(*Event), 0, len(c.subs[msg.Topic]))
+	for _, sub := range c.subs[msg.Topic] {
+		if len(sub.types) == 0 || sub.types[e.Type] {
+			fns = append(fns, sub.fn)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, fn := range fns {
+		fn(e)
+	}
+}
+
+// send sends a message with a new ref, errors are logged: a broken connection is noticed by the receive loop.
+func (c *Client) send(conn *websocket.Conn, topic, event string) {
+	if err := c.sendRef(conn, topic, event, c.ref.Add(1)); err != nil {
+		c.logger().Warn("opensea stream send", "topic", topic, "event", event, "error", err)
+	}
+}
+
+func (c *Client) sendRef(conn *websocket.Conn, topic, event string, ref uint64) error {
+	r := strconv.FormatUint(ref, 10)
+	err := websocket.JSON.Send(conn, &message{
+		Topic:   topic,
+		Event:   event,
+		Payload: json.RawMessage(`{}`),
+		Ref:     &r,
+	})
+	return errx.WithStack(err)
+}
@@ -0,0 +1,385 @@
+package openseastream
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/xTransact/openseaapi/openseaenums"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+// fakePhoenix is a local stand-in of the Stream API answering joins, leaves and heartbeats like a Phoenix server.
+type fakePhoenix struct {
+	srv *httptest.Server
+
+	mu             sync.Mutex
+	conns          []*websocket.Conn
+	tokens         []string
+	leaves         []string
+	muteHeartbeats bool
+	// crashes is the number of joins followed by a phx_error of the channel
+	crashes int
+	joins   chan string
+	beats   chan struct{}
+}
+
+func newFakePhoenix(t *testing.T) *fakePhoenix {
+	f := &fakePhoenix{
+		joins: make(chan string, 100),
+		beats: make(chan struct{}, 100),
+	}
+	f.srv = httptest.NewServer(websocket.Handler(f.serve))
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakePhoenix) url() string {
+	return "ws" + strings.TrimPrefix(f.srv.URL, "http") + "/socket/websocket"
+}
+
+func (f *fakePhoenix) serve(ws *websocket.Conn) {
+	f.mu.Lock()
+	f.conns = append(f.conns, ws)
+	f.tokens = append(f.tokens, ws.Request().URL.Query().Get("token"))
+	f.mu.Unlock()
+
+	for {
+		var msg message
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			return
+		}
+
+		switch msg.Event {
+		case eventJoin:
+			f.reply(ws, &msg)
+			f.joins <- msg.Topic
+
+			f.mu.Lock()
+			crash := f.crashes > 0
+			if crash {
+				f.crashes--
+			}
+			f.mu.Unlock()
+			if crash {
+				_ = websocket.JSON.Send(ws, &message{Topic: msg.Topic, Event: eventError, Payload: []byte(`{}`)})
+			}
+		case eventLeave:
+			f.mu.Lock()
+			f.leaves = append(f.leaves, msg.Topic)
+			f.mu.Unlock()
+			f.reply(ws, &msg)
+		case eventHeartbeat:
+			f.mu.Lock()
+			mute := f.muteHeartbeats
+			f.mu.Unlock()
+			f.beats <- struct{}{}
+			if !mute {
+				f.reply(ws, &msg)
+			}
+		}
+	}
+}
+
+func (f *fakePhoenix) reply(ws *websocket.Conn, msg *message) {
+	_ = websocket.JSON.Send(ws, &message{
+		Topic:   msg.Topic,
+		Event:   eventReply,
+		Payload: []byte(`{"status":"ok","response":{}}`),
+		Ref:     msg.Ref,
+	})
+}
+
+// push pushes an event on the topic through the last connection.
+func (f *fakePhoenix) push(t *testing.T, topic string, eventType openseaenums.StreamEventType, payload string) {
+	f.mu.Lock()
+	ws := f.conns[len(f.conns)-1]
+	f.mu.Unlock()
+
+	err := websocket.JSON.Send(ws, &message{
+		Topic: topic,
+		Event: string(eventType),
+		Payload: []byte(fmt.Sprintf(`{"event_type":%q,"sent_at":"2024-01-01T00:00:00.000000+00:00","payload":%s}`,
+			eventType, payload)),
+	})
+	require.NoError(t, err)
+}
+
+// drop closes every connection, as a server restart would.
+func (f *fakePhoenix) drop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, ws := range f.conns {
+		_ = ws.Close()
+	}
+}
+
+func (f *fakePhoenix) waitJoin(t *testing.T, topic string) {
+	select {
+	case got := <-f.joins:
+		assert.Equal(t, topic, got)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for the join of %s", topic)
+	}
+}
+
+// run runs the client until the end of the test.
+func run(t *testing.T, cli *Client) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- cli.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(5 * time.Second):
+			t.Error("Run did not return after cancel")
+		}
+	})
+}
+
+const testSalePayload = `{"event_timestamp":"2024-01-01T00:00:00.000000+00:00","collection":{"slug":"azuki"},
+	"item":{"nft_id":"ethereum/0xed5af388653567af2f388e6224dc7c4b3241c544/7","permalink":"https://opensea.io/assets/ethereum/0xed5af388653567af2f388e6224dc7c4b3241c544/7",
+		"metadata":{"name":"Azuki #7"},"chain":{"name":"ethereum"}},
+	"sale_price":"1500000000000000000","quantity":1,"order_hash":"0xabc",
+	"payment_token":{"symbol":"ETH","address":"0x0000000000000000000000000000000000000000","decimals":18,"eth_price":"1","usd_price":"3456.78"},
+	"maker":{"address":"0x01"},"taker":{"address":"0x02"},"transaction":{"hash":"0xtx","timestamp":"2024-01-01T00:00:00.000000+00:00"}}`
+
+func TestOnItemSold(t *testing.T) {
+	f := newFakePhoenix(t)
+	cli := NewClient(WithURL(f.url()), WithApiKey("secret"))
+
+	sold := make(chan *openseamodels.ItemSoldEvent, 10)
+	cli.OnItemSold("azuki", func(e *openseamodels.ItemSoldEvent) {
+		sold <- e
+	})
+	run(t, cli)
+	f.waitJoin(t, "collection:azuki")
+
+	// item_listed 不属于该订阅，不会被分发
+	f.push(t, "collection:azuki", openseaenums.StreamEventItemListed, `{"collection":{"slug":"azuki"},"base_price":"1"}`)
+	f.push(t, "collection:azuki", openseaenums.StreamEventItemSold, testSalePayload)
+
+	select {
+	case e := <-sold:
+		assert.Equal(t, "azuki", e.Collection.Slug)
+		assert.Equal(t, "0xabc", e.OrderHash)
+		assert.True(t, decimal.RequireFromString("1500000000000000000").Equal(e.SalePrice))
+		require.NotNil(t, e.PaymentToken)
+		assert.True(t, decimal.RequireFromString("3456.78").Equal(e.PaymentToken.UsdPrice))
+		assert.Equal(t, "0xtx", e.Transaction.Hash)
+		ch, contract, id, ok := e.Item.NftID()
+		require.True(t, ok)
+		assert.Equal(t, []string{"ethereum", "0xed5af388653567af2f388e6224dc7c4b3241c544", "7"}, []string{ch, contract, id})
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for item_sold")
+	}
+	assert.Len(t, sold, 0)
+
+	f.mu.Lock()
+	assert.Equal(t, []string{"secret"}, f.tokens)
+	f.mu.Unlock()
+}
+
+func TestSubscribeAllCollections(t *testing.T) {
+	f := newFakePhoenix(t)
+	cli := NewClient(WithURL(f.url()))
+
+	events := make(chan *Event, 10)
+	cli.Subscribe(AllCollections, func(e *Event) {
+		events <- e
+	})
+	run(t, cli)
+	f.waitJoin(t, "collection:*")
+
+	f.push(t, "collection:*", openseaenums.StreamEventTraitOffer, `{"collection":{"slug":"doodles"},
+		"base_price":"200","trait_criteria":{"trait_type":"Background","trait_name":"Red"}}`)
+
+	select {
+	case e := <-events:
+		assert.Equal(t, openseaenums.StreamEventTraitOffer, e.Type)
+		assert.Equal(t, "doodles", e.Collection)
+		assert.Equal(t, "2024-01-01T00:00:00.000000+00:00", e.SentAt)
+
+		v, err := e.Decode()
+		require.NoError(t, err)
+		offer, ok := v.(*openseamodels.TraitOfferEvent)
+		require.True(t, ok)
+		assert.Equal(t, "Background", offer.TraitCriteria.TraitType)
+		assert.Equal(t, "Red", offer.TraitCriteria.TraitName)
+		assert.True(t, decimal.NewFromInt(200).Equal(offer.BasePrice))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for trait_offer")
+	}
+}
+
+func TestSubscribeWhileConnected(t *testing.T) {
+	f := newFakePhoenix(t)
+	cli := NewClient(WithURL(f.url()))
+
+	unsubscribe := cli.Subscribe("azuki", func(*Event) {})
+	run(t, cli)
+	f.waitJoin(t, "collection:azuki")
+
+	listed := make(chan *openseamodels.ItemListedEvent, 1)
+	cli.OnItemListed("doodles", func(e *openseamodels.ItemListedEvent) {
+		listed <- e
+	})
+	f.waitJoin(t, "collection:doodles")
+
+	f.push(t, "collection:doodles", openseaenums.StreamEventItemListed, `{"collection":{"slug":"doodles"},"base_price":"42"}`)
+	select {
+	case e := <-listed:
+		assert.True(t, decimal.NewFromInt(42).Equal(e.BasePrice))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for item_listed")
+	}
+
+	unsubscribe()
+	unsubscribe()
+	assert.Eventually(t, func() bool {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return len(f.leaves) == 1 && f.leaves[0] == "collection:azuki"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReconnectResubscribes(t *testing.T) {
+	f := newFakePhoenix(t)
+	cli := NewClient(WithURL(f.url()), WithReconnectDelay(10*time.Millisecond, 50*time.Millisecond))
+
+	sold := make(chan *openseamodels.ItemSoldEvent, 10)
+	cli.OnItemSold("azuki", func(e *openseamodels.ItemSoldEvent) {
+		sold <- e
+	})
+	run(t, cli)
+	f.waitJoin(t, "collection:azuki")
+
+	f.drop()
+	f.waitJoin(t, "collection:azuki")
+
+	f.push(t, "collection:azuki", openseaenums.StreamEventItemSold, testSalePayload)
+	select {
+	case e := <-sold:
+		assert.Equal(t, "0xabc", e.OrderHash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for item_sold after reconnect")
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	f := newFakePhoenix(t)
+	cli := NewClient(WithURL(f.url()),
+		WithHeartbeatInterval(20*time.Millisecond),
+		WithReconnectDelay(10*time.Millisecond, 50*time.Millisecond))
+
+	cli.Subscribe("azuki", func(*Event) {})
+	run(t, cli)
+	f.waitJoin(t, "collection:azuki")
+
+	// 心跳有回应时连接保持
+	for i := 0; i < 3; i++ {
+		select {
+		case <-f.beats:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for heartbeat")
+		}
+	}
+	assert.Len(t, f.joins, 0)
+
+	// 心跳无回应时重连并重新订阅
+	f.mu.Lock()
+	f.muteHeartbeats = true
+	f.mu.Unlock()
+	f.waitJoin(t, "collection:azuki")
+
+	f.mu.Lock()
+	assert.GreaterOrEqual(t, len(f.conns), 2)
+	f.mu.Unlock()
+}
+
+func TestRejoinBackoff(t *testing.T) {
+	f := newFakePhoenix(t)
+	f.crashes = 3
+	cli := NewClient(WithURL(f.url()), WithReconnectDelay(50*time.Millisecond, 100*time.Millisecond))
+
+	cli.Subscribe("azuki", func(*Event) {})
+	run(t, cli)
+
+	var joins []time.Time
+	for i := 0; i < 4; i++ {
+		f.waitJoin(t, "collection:azuki")
+		joins = append(joins, time.Now())
+	}
+
+	// 每次 phx_error 后延迟加倍重新加入，不超过上限
+	for i, delay := range []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond} {
+		assert.GreaterOrEqual(t, joins[i+1].Sub(joins[i]), delay-10*time.Millisecond, "rejoin %d", i+1)
+	}
+	f.mu.Lock()
+	assert.Len(t, f.conns, 1)
+	f.mu.Unlock()
+}
+
+func TestRunCancelsDial(t *testing.T) {
+	// 接受连接但从不完成 websocket 握手
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	var (
+		mu    sync.Mutex
+		conns []net.Conn
+	)
+	t.Cleanup(func() {
+		_ = l.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	})
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+		}
+	}()
+
+	cli := NewClient(WithURL("ws://" + l.Addr().String() + "/socket/websocket"))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = cli.Run(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestDecodeMalformedAddress(t *testing.T) {
+	// 格式异常的地址不影响事件的解析
+	payload := strings.Replace(testSalePayload, `"address":"0x0000000000000000000000000000000000000000"`, `"address":"eth"`, 1)
+	e := &Event{Type: openseaenums.StreamEventItemSold, Payload: []byte(payload)}
+	v, err := e.Decode()
+	require.NoError(t, err)
+	sold := v.(*openseamodels.ItemSoldEvent)
+	require.NotNil(t, sold.PaymentToken)
+	assert.Equal(t, "eth", sold.PaymentToken.Address.String())
+	assert.False(t, sold.PaymentToken.Address.IsValid())
+}
@@ -0,0 +1,69 @@
+package openseastream
+
+import (
+	"encoding/json"
+
+	"github.com/xTransact/openseaapi/openseaenums"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+// OnItemListed calls fn when an NFT of the collection is listed for sale.
+func (c *Client) OnItemListed(slug string, fn func(*openseamodels.ItemListedEvent)) (unsubscribe func()) {
+	return on(c, slug, openseaenums.StreamEventItemListed, fn)
+}
+
+// OnItemSold calls fn when an NFT of the collection is sold.
+func (c *Client) OnItemSold(slug string, fn func(*openseamodels.ItemSoldEvent)) (unsubscribe func()) {
+	return on(c, slug, openseaenums.StreamEventItemSold, fn)
+}
+
+// OnItemTransferred calls fn when an NFT of the collection is transferred between wallets.
+func (c *Client) OnItemTransferred(slug string, fn func(*openseamodels.ItemTransferredEvent)) (unsubscribe func()) {
+	return on(c, slug, openseaenums.StreamEventItemTransferred, fn)
+}
+
+// OnItemMetadataUpdated calls fn when the metadata of an NFT of the collection is updated.
+func (c *Client) OnItemMetadataUpdated(slug string,
+	fn func(*openseamodels.ItemMetadataUpdatedEvent)) (unsubscribe func()) {
+
+	return on(c, slug, openseaenums.StreamEventItemMetadataUpdated, fn)
+}
+
+// OnItemCancelled calls fn when a listing of an NFT of the collection is cancelled.
+func (c *Client) OnItemCancelled(slug string, fn func(*openseamodels.ItemCancelledEvent)) (unsubscribe func()) {
+	return on(c, slug, openseaenums.StreamEventItemCancelled, fn)
+}
+
+// OnItemReceivedOffer calls fn when an NFT of the collection receives an offer.
+func (c *Client) OnItemReceivedOffer(slug string,
+	fn func(*openseamodels.ItemReceivedOfferEvent)) (unsubscribe func()) {
+
+	return on(c, slug, openseaenums.StreamEventItemReceivedOffer, fn)
+}
+
+// OnItemReceivedBid calls fn when an NFT of the collection listed as an auction receives a bid.
+func (c *Client) OnItemReceivedBid(slug string, fn func(*openseamodels.ItemReceivedBidEvent)) (unsubscribe func()) {
+	return on(c, slug, openseaenums.StreamEventItemReceivedBid, fn)
+}
+
+// OnCollectionOffer calls fn when the collection receives an offer.
+func (c *Client) OnCollectionOffer(slug string, fn func(*openseamodels.CollectionOfferEvent)) (unsubscribe func()) {
+	return on(c, slug, openseaenums.StreamEventCollectionOffer, fn)
+}
+
+// OnTraitOffer calls fn when a trait of the collection receives an offer.
+func (c *Client) OnTraitOffer(slug string, fn func(*openseamodels.TraitOfferEvent)) (unsubscribe func()) {
+	return on(c, slug, openseaenums.StreamEventTraitOffer, fn)
+}
+
+// on subscribes fn to the events of type t, decoding their payload into T. Undecodable events are logged and skipped.
+func on[T any](c *Client, slug string, t openseaenums.StreamEventType, fn func(*T)) (unsubscribe func()) {
+	return c.Subscribe(slug, func(e *Event) {
+		v := new(T)
+		if err := json.Unmarshal(e.Payload, v); err != nil {
+			c.logger().Error("opensea stream unmarshal payload", "event", e.Type, "error", err)
+			return
+		}
+		fn(v)
+	}, t)
+}
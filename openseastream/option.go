@@ -0,0 +1,76 @@
+package openseastream
+
+import (
+	"log/slog"
+	"time"
+)
+
+const (
+	// StreamUrlProd is the Stream API endpoint of the mainnets.
+	StreamUrlProd = "wss://stream.openseabeta.com/socket/websocket"
+	// StreamUrlTest is the Stream API endpoint of the testnets.
+	StreamUrlTest = "wss://testnets-stream.openseabeta.com/socket/websocket"
+
+	// DefaultHeartbeatInterval is the interval of the heartbeats expected by the Phoenix server.
+	DefaultHeartbeatInterval = 30 * time.Second
+	// DefaultMinReconnectDelay is the delay before the first reconnection attempt.
+	DefaultMinReconnectDelay = time.Second
+	// DefaultMaxReconnectDelay caps the delay between reconnection attempts, doubled after every failure.
+	DefaultMaxReconnectDelay = 30 * time.Second
+)
+
+type options struct {
+	apiKey            string
+	testnets          bool
+	url               string
+	logger            *slog.Logger
+	heartbeatInterval time.Duration
+	minReconnectDelay time.Duration
+	maxReconnectDelay time.Duration
+}
+
+type OptionFn func(*options)
+
+func WithApiKey(key string) OptionFn {
+	return func(o *options) {
+		o.apiKey = key
+	}
+}
+
+// WithTestnets streams the events of the testnets instead of the mainnets.
+func WithTestnets() OptionFn {
+	return func(o *options) {
+		o.testnets = true
+	}
+}
+
+// WithURL overrides the Stream API endpoint, e.g. to go through a proxy. It takes precedence over WithTestnets.
+func WithURL(url string) OptionFn {
+	return func(o *options) {
+		o.url = url
+	}
+}
+
+// WithLogger sets the logger of the client. Default: slog.Default()
+func WithLogger(logger *slog.Logger) OptionFn {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithHeartbeatInterval sets the interval of the heartbeats. Default: DefaultHeartbeatInterval
+// The connection is considered dead, and reopened, when a heartbeat is not answered before the next one.
+func WithHeartbeatInterval(interval time.Duration) OptionFn {
+	return func(o *options) {
+		o.heartbeatInterval = interval
+	}
+}
+
+// WithReconnectDelay sets the bounds of the exponential backoff between reconnection attempts,
+// and between the rejoins of a channel closed by the server.
+// Default: DefaultMinReconnectDelay and DefaultMaxReconnectDelay
+func WithReconnectDelay(min, max time.Duration) OptionFn {
+	return func(o *options) {
+		o.minReconnectDelay, o.maxReconnectDelay = min, max
+	}
+}
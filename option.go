@@ -1,13 +1,38 @@
 package openseaapi
 
-import "time"
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/xTransact/openseaapi/chain"
+)
 
 type options struct {
-	apiKey     string
-	privateKey string
-	withHost   map[string]string
-	verbose    bool
-	timeout    time.Duration
+	apiKey          string
+	privateKey      string
+	withHost        map[string]string
+	verbose         bool
+	timeout         time.Duration
+	baseURL         string
+	testnetsBaseURL string
+	httpClient      *http.Client
+	transport       http.RoundTripper
+	retryPolicy     RetryPolicy
+	rateLimiter     *RateLimiter
+	middlewares     []Middleware
+	logger          *slog.Logger
+	dumpBody        bool
+	tracerProvider  trace.TracerProvider
+	metrics         *MetricsCollector
+	cache           Cache
+	cacheTTLs       map[string]time.Duration
+	coalesce        bool
+	keyPool         *KeyPool
+	breaker         *CircuitBreaker
+	signer          Signer
 }
 
 type OptionFn func(*options)
@@ -18,12 +43,45 @@ func WithApiKey(key string) OptionFn {
 	}
 }
 
+// WithApiKeys spreads the requests across several API keys in turn, benching the keys answered with 429 or 401.
+// Use WithKeyPool to select another strategy, read the usage counters or swap the keys at runtime.
+func WithApiKeys(keys ...string) OptionFn {
+	return func(o *options) {
+		o.keyPool = NewKeyPool(KeyStrategyRoundRobin, keys...)
+	}
+}
+
+// WithKeyPool spreads the requests across the keys of a pool which may be shared by other clients.
+// It takes precedence over WithApiKey.
+func WithKeyPool(pool *KeyPool) OptionFn {
+	return func(o *options) {
+		o.keyPool = pool
+	}
+}
+
+// WithPrivateKey signs the orders of CreateListing and CreateIndividualOffer with a hex encoded private key,
+// see WithSigner. An invalid key fails these calls.
+func WithPrivateKey(key string) OptionFn {
+	return func(o *options) {
+		o.privateKey = key
+	}
+}
+
+// WithSigner signs the orders of CreateListing and CreateIndividualOffer whose signature is empty,
+// filling an empty offerer with the address of the signer. It takes precedence over WithPrivateKey.
+func WithSigner(signer Signer) OptionFn {
+	return func(o *options) {
+		o.signer = signer
+	}
+}
+
 func WithHost(host map[string]string) OptionFn {
 	return func(o *options) {
 		o.withHost = host
 	}
 }
 
+// EnableVerbose logs every request with its method, url, query, latency, status and response size.
 func EnableVerbose() OptionFn {
 	return func(o *options) {
 		o.verbose = true
@@ -36,8 +94,152 @@ func WithTimeout(timeout time.Duration) OptionFn {
 	}
 }
 
+// EnableBodyDump additionally logs the request and response bodies of verbose logging.
+// The x-api-key header and order signatures are redacted.
+func EnableBodyDump() OptionFn {
+	return func(o *options) {
+		o.verbose = true
+		o.dumpBody = true
+	}
+}
+
+// WithLogger sets the logger of the client. Default: slog.Default()
+func WithLogger(logger *slog.Logger) OptionFn {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithTracerProvider starts an OpenTelemetry span for every call of the client,
+// as a child of the span found in the context of the call.
+func WithTracerProvider(provider trace.TracerProvider) OptionFn {
+	return func(o *options) {
+		o.tracerProvider = provider
+	}
+}
+
+// WithMetrics feeds the prometheus collector with the calls of the client.
+// The collector may be shared by several clients, registering it is up to the caller.
+func WithMetrics(metrics *MetricsCollector) OptionFn {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// WithCache serves the slowly changing endpoints from the cache, see DefaultCacheTTLs.
+// POST endpoints and the order and offer endpoints are never cached.
+func WithCache(cache Cache) OptionFn {
+	return func(o *options) {
+		o.cache = cache
+	}
+}
+
+// WithCacheTTL overrides the TTL of an endpoint cached by WithCache, e.g. WithCacheTTL(EndpointGetNft, time.Minute).
+// A ttl <= 0 disables the cache for the endpoint.
+// It also overrides DefaultPaymentTokenTTL for EndpointGetPaymentToken, which has its own in-process cache instead.
+func WithCacheTTL(endpoint string, ttl time.Duration) OptionFn {
+	return func(o *options) {
+		if o.cacheTTLs == nil {
+			o.cacheTTLs = make(map[string]time.Duration)
+		}
+		o.cacheTTLs[endpoint] = ttl
+	}
+}
+
+// WithCoalescing makes identical concurrent GET calls share a single in-flight http call,
+// identical meaning same URL, query, environment and per-request API key and headers.
+// Every caller still decodes its own copy of the response.
+// Waiters may give up through their context, the shared call is only aborted when all of them did.
+// The shared call keeps the deadline of the caller starting it, callers with a later deadline start their own call.
+// The saved calls are counted by WithMetrics.
+func WithCoalescing() OptionFn {
+	return func(o *options) {
+		o.coalesce = true
+	}
+}
+
+// WithCircuitBreaker fails the calls fast with a *CircuitOpenError while OpenSea is failing the calls of their
+// endpoint group, instead of retrying them. The breaker may be shared by several clients.
+func WithCircuitBreaker(breaker *CircuitBreaker) OptionFn {
+	return func(o *options) {
+		o.breaker = breaker
+	}
+}
+
+// WithHTTPClient replaces the default uTLS fingerprinting http client.
+// The client is copied, so applying WithTimeout or WithTransport never mutates the caller's instance.
+func WithHTTPClient(httpClient *http.Client) OptionFn {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport replaces the transport of the http client,
+// e.g. to add a proxy, custom TLS roots or a fake transport in tests.
+func WithTransport(transport http.RoundTripper) OptionFn {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// WithRetryPolicy sets the retry policy of the client. Default: DefaultRetryPolicy()
+func WithRetryPolicy(policy RetryPolicy) OptionFn {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithRateLimit throttles the client to readPerSecond read requests and postPerSecond marketplace POST requests,
+// see RateLimitScope.
+// Use WithRateLimiter to share the budget between several clients.
+func WithRateLimit(readPerSecond, postPerSecond float64) OptionFn {
+	return func(o *options) {
+		o.rateLimiter = NewRateLimiter(readPerSecond, postPerSecond)
+	}
+}
+
+// WithRateLimiter throttles the client with a limiter which may be shared by other clients,
+// e.g. all the clients using the same API key.
+func WithRateLimiter(limiter *RateLimiter) OptionFn {
+	return func(o *options) {
+		o.rateLimiter = limiter
+	}
+}
+
+// WithMiddleware appends middlewares around every call of the client.
+// The first middleware is the outermost one, it sees the call first and the response last.
+func WithMiddleware(middlewares ...Middleware) OptionFn {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// WithBaseURL overrides the mainnet and testnets API base URLs used by the client,
+// e.g. to point it at a local stand-in server or an internal gateway.
+// An empty value keeps the default OpenSea URL for that environment.
+func WithBaseURL(mainnet, testnets string) OptionFn {
+	return func(o *options) {
+		o.baseURL = mainnet
+		o.testnetsBaseURL = testnets
+	}
+}
+
 type requestOptions struct {
-	testnets bool
+	testnets        bool
+	apiKey          string
+	timeout         time.Duration
+	header          http.Header
+	baseURL         string
+	testnetsBaseURL string
+	retryPolicy     RetryPolicy
+	cacheControl    CacheControl
+	responseMeta    *ResponseMeta
+	scanOrderPages  bool
+
+	// 以下字段由各 endpoint 设置，供 middleware 使用
+	endpoint string
+	chain    chain.Chain
+	payload  any
 }
 
 type RequestOptionFn func(*requestOptions)
@@ -47,3 +249,86 @@ func UseTestnets() RequestOptionFn {
 		o.testnets = true
 	}
 }
+
+// UseApiKey overrides the API key of the client, or its key pool, for a single request.
+func UseApiKey(key string) RequestOptionFn {
+	return func(o *requestOptions) {
+		o.apiKey = key
+	}
+}
+
+// UseTimeout bounds a single request, retries included, on top of the deadline of its context.
+func UseTimeout(timeout time.Duration) RequestOptionFn {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// UseHeader adds a header to a single request, e.g. a tracing or an idempotency header.
+// It may be used several times, the values of a same key are all sent.
+func UseHeader(key, value string) RequestOptionFn {
+	return func(o *requestOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// UseBaseURL overrides the mainnet and testnets API base URLs for a single request.
+// An empty value falls back to the client configuration.
+func UseBaseURL(mainnet, testnets string) RequestOptionFn {
+	return func(o *requestOptions) {
+		o.baseURL = mainnet
+		o.testnetsBaseURL = testnets
+	}
+}
+
+// UseRetryPolicy overrides the retry policy of the client for a single request,
+// e.g. UseRetryPolicy(NoRetryPolicy()) for latency sensitive calls.
+func UseRetryPolicy(policy RetryPolicy) RequestOptionFn {
+	return func(o *requestOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// UseCacheControl selects how a single request uses the cache of the client, see WithCache.
+func UseCacheControl(control CacheControl) RequestOptionFn {
+	return func(o *requestOptions) {
+		o.cacheControl = control
+	}
+}
+
+// BypassCache neither reads nor writes the cache of the client for a single request.
+func BypassCache() RequestOptionFn {
+	return func(o *requestOptions) {
+		o.cacheControl = CacheBypass
+	}
+}
+
+// RefreshCache skips the cached response for a single request and caches the fresh one.
+func RefreshCache() RequestOptionFn {
+	return func(o *requestOptions) {
+		o.cacheControl = CacheRefresh
+	}
+}
+
+// UseResponseMeta fills meta with the status, headers, retries and raw body of the response of a single request.
+// It is filled on failures too, when a response was received.
+func UseResponseMeta(meta *ResponseMeta) RequestOptionFn {
+	return func(o *requestOptions) {
+		o.responseMeta = meta
+	}
+}
+
+// ScanOrderPages computes GetBestListing, GetBestListings and GetBestOffer from the full order pages of the collection
+// instead of calling the best order endpoints. They already fall back to such a scan when OpenSea answers that it
+// does not serve the endpoint, this option skips the call for the collections known to need it.
+// A scan fetches every order page of the collection and compares the orders per NFT, private listings excluded.
+// It fails when the orders are paid in several payment tokens, unless the PaymentToken of the payload selects one.
+// GetBestListings then returns a single page without next cursor, cut to the limit of the payload when set.
+func ScanOrderPages() RequestOptionFn {
+	return func(o *requestOptions) {
+		o.scanOrderPages = true
+	}
+}
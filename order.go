@@ -1,6 +1,7 @@
 package openseaapi
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,15 +10,14 @@ import (
 	"github.com/xTransact/errx/v3"
 
 	"github.com/xTransact/openseaapi/chain"
-	"github.com/xTransact/openseaapi/openseaapiutils"
 	"github.com/xTransact/openseaapi/openseamodels"
 )
 
 // GetOrder gets a single order, offer or listing, by its order hash.
 // Protocol and Chain are required to prevent hash collisions.
 // DOC: https://docs.opensea.io/reference/get_order
-func (c *client) GetOrder(ctx context.Context, payload *openseamodels.GetOrderPayload) (
-	resp *openseamodels.GetOrderResponse, err error) {
+func (c *client) GetOrder(ctx context.Context, payload *openseamodels.GetOrderPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.GetOrderResponse, err error) {
 
 	if err = payload.Validate(); err != nil {
 		return nil, err
@@ -25,10 +25,17 @@ func (c *client) GetOrder(ctx context.Context, payload *openseamodels.GetOrderPa
 
 	ch := chain.RequireFromString(payload.Chain)
 
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointGetOrder, ch, payload
+
 	// GET /api/v2/orders/chain/{chain}/protocol/{protocol_address}/{order_hash}
 
 	url := fmt.Sprintf("%s/api/v2/orders/chain/%s/protocol/%s/%s",
-		openseaapiutils.GetBaseURLByChain(ch), ch.Value(), payload.ProtocolAddress, payload.OrderHash)
+		c.baseURL(o), ch.Value(), payload.ProtocolAddress, payload.OrderHash)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -36,7 +43,7 @@ func (c *client) GetOrder(ctx context.Context, payload *openseamodels.GetOrderPa
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, ch.IsTestNet())
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}
@@ -48,3 +55,56 @@ func (c *client) GetOrder(ctx context.Context, payload *openseamodels.GetOrderPa
 
 	return resp, nil
 }
+
+// CancelOrder cancels an order off-chain, preventing it from being fulfilled.
+// Only orders protected by the SignedZone, e.g. those created through the API, can be cancelled this way.
+// The offerer signature is optional when the order belongs to the user of the API key.
+// It is required on testnets since the API key is only sent to the mainnet API.
+// DOC: https://docs.opensea.io/reference/cancel_order
+func (c *client) CancelOrder(ctx context.Context, payload *openseamodels.CancelOrderPayload,
+	opts ...RequestOptionFn) (resp *openseamodels.CancelOrderResponse, err error) {
+
+	if err = payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	payloadData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errx.Wrap(err, "marshal payload")
+	}
+
+	ch := chain.RequireFromString(payload.Chain)
+	if ch.IsTestNet() && payload.OffererSignature == "" {
+		return nil, errx.New("offerer_signature must not be empty on testnets")
+	}
+
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointCancelOrder, ch, payload
+
+	// POST /api/v2/orders/chain/{chain}/protocol/{protocol_address}/{order_hash}/cancel
+	url := fmt.Sprintf("%s/api/v2/orders/chain/%s/protocol/%s/%s/cancel",
+		c.baseURL(o), ch.Value(), payload.ProtocolAddress, payload.OrderHash)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payloadData))
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+
+	c.acceptJson(req)
+	c.contentTypeJson(req)
+	body, err := c.doRequest(req, o)
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+
+	resp = new(openseamodels.CancelOrderResponse)
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errx.Wrap(err, "unmarshal response body")
+	}
+
+	return resp, nil
+}
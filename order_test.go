@@ -0,0 +1,75 @@
+package openseaapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+func TestCancelOrder(t *testing.T) {
+	var (
+		method, path string
+		body         map[string]any
+	)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		data, _ := io.ReadAll(r.Body)
+		body = nil
+		_ = json.Unmarshal(data, &body)
+		_, _ = w.Write([]byte(`{"last_signature_issued_valid_until":"2024-01-01T00:00:00.000000"}`))
+	}
+	mainnet := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/chain/ethereum/") {
+			t.Error("mainnet must not be called for a testnet order")
+		}
+		handler(w, r)
+	})
+	testnets := newTestServer(t, handler)
+
+	ctx := context.Background()
+	cli := NewClient(WithBaseURL(mainnet.URL, testnets.URL), WithApiKey("key"))
+
+	resp, err := cli.CancelOrder(ctx, &openseamodels.CancelOrderPayload{
+		Chain:            chain.Sepolia.Value(),
+		OrderHash:        "0xabc",
+		ProtocolAddress:  "0x0000000000000068F116a894984e2DB1123eB395",
+		OffererSignature: "0xsig",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-01T00:00:00.000000", resp.LastSignatureIssuedValidUntil)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/api/v2/orders/chain/sepolia/protocol/0x0000000000000068F116a894984e2DB1123eB395/0xabc/cancel", path)
+	assert.Equal(t, map[string]any{"offerer_signature": "0xsig"}, body)
+
+	// 不带签名时请求体为空对象，由 API key 对应的用户取消
+	_, err = cli.CancelOrder(ctx, &openseamodels.CancelOrderPayload{
+		Chain:           chain.Ethereum.Value(),
+		OrderHash:       "0xabc",
+		ProtocolAddress: "0x0000000000000068F116a894984e2DB1123eB395",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v2/orders/chain/ethereum/protocol/0x0000000000000068F116a894984e2DB1123eB395/0xabc/cancel", path)
+	assert.Empty(t, body)
+
+	// 测试网不发送 API key，必须带签名
+	path = ""
+	_, err = cli.CancelOrder(ctx, &openseamodels.CancelOrderPayload{
+		Chain:           chain.Sepolia.Value(),
+		OrderHash:       "0xabc",
+		ProtocolAddress: "0x0000000000000068F116a894984e2DB1123eB395",
+	})
+	assert.Error(t, err)
+	assert.Empty(t, path)
+
+	_, err = cli.CancelOrder(ctx, &openseamodels.CancelOrderPayload{Chain: chain.Sepolia.Value()})
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package openseaapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/xTransact/errx/v3"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+const (
+	// paymentTokenCacheSize is the number of payment tokens kept by the in-process cache of GetPaymentToken.
+	paymentTokenCacheSize = 256
+	// DefaultPaymentTokenTTL is how long GetPaymentToken serves a payment token from its in-process cache,
+	// override it with WithCacheTTL(EndpointGetPaymentToken, ttl).
+	// The payment tokens are never stored in the cache of WithCache.
+	DefaultPaymentTokenTTL = time.Minute
+)
+
+// GetPaymentToken gets a payment token, e.g. WETH, including its symbol, decimals, and ETH and USD prices.
+// Payment tokens are kept in an in-process cache for DefaultPaymentTokenTTL, since their prices are read for every sale.
+// This cache replaces the one of WithCache for the endpoint and, like it, is served from within the call chain:
+// hits are traced and fill UseResponseMeta. BypassCache and RefreshCache apply to this cache.
+// @param ch (chain.Chain): required: The blockchain on which the payment token is deployed.
+// @param address: required: The unique public blockchain identifier for the payment token.
+// DOC: https://docs.opensea.io/reference/get_payment_token
+func (c *client) GetPaymentToken(ctx context.Context, ch chain.Chain, address chain.Address,
+	opts ...RequestOptionFn) (resp *openseamodels.PaymentToken, err error) {
+
+	if err = validateAddress(ch, address); err != nil {
+		return nil, err
+	}
+
+	o := new(requestOptions)
+	for _, apply := range opts {
+		apply(o)
+	}
+	o.testnets = ch.IsTestNet()
+	o.endpoint, o.chain, o.payload = EndpointGetPaymentToken, ch, address
+
+	// GET /api/v2/chain/{chain}/payment_token/{address}
+	url := fmt.Sprintf("%s/api/v2/chain/%s/payment_token/%s",
+		c.baseURL(o), ch.Value(), address.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+
+	c.acceptJson(req)
+	body, err := c.doRequest(req, o)
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+
+	resp = new(openseamodels.PaymentToken)
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errx.Wrap(err, "unmarshal response body")
+	}
+
+	return resp, nil
+}
+
+func (c *client) paymentTokenTTL() time.Duration {
+	if ttl, ok := c.config.cacheTTLs[EndpointGetPaymentToken]; ok {
+		return ttl
+	}
+	return DefaultPaymentTokenTTL
+}
@@ -0,0 +1,120 @@
+package openseaapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/chain"
+)
+
+func TestGetPaymentToken(t *testing.T) {
+	weth := chain.RequireAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+
+	var paths []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"symbol":"WETH","address":"0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2","chain":"ethereum",
+			"name":"Wrapped Ether","decimals":18,"eth_price":"1.000000000000000","usd_price":"3456.780000000000"}`))
+	})
+
+	ctx := context.Background()
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL))
+
+	resp, err := cli.GetPaymentToken(ctx, chain.Ethereum, weth)
+	require.NoError(t, err)
+	assert.Equal(t, "WETH", resp.Symbol)
+	assert.Equal(t, weth, resp.Address)
+	assert.Equal(t, 18, resp.Decimals)
+	assert.True(t, decimal.NewFromInt(1).Equal(resp.EthPrice))
+	assert.True(t, decimal.RequireFromString("3456.78").Equal(resp.UsdPrice))
+	assert.Equal(t, []string{"/api/v2/chain/ethereum/payment_token/" + weth.String()}, paths)
+
+	// 第二次调用命中进程内缓存
+	resp, err = cli.GetPaymentToken(ctx, chain.Ethereum, weth)
+	require.NoError(t, err)
+	assert.Equal(t, "WETH", resp.Symbol)
+	assert.Len(t, paths, 1)
+
+	_, err = cli.GetPaymentToken(ctx, chain.Ethereum, weth, BypassCache())
+	require.NoError(t, err)
+	_, err = cli.GetPaymentToken(ctx, chain.Ethereum, weth, RefreshCache())
+	require.NoError(t, err)
+	assert.Len(t, paths, 3)
+
+	// 其他链上的同一地址是另一个代币
+	_, err = cli.GetPaymentToken(ctx, chain.Base, weth)
+	require.NoError(t, err)
+	assert.Len(t, paths, 4)
+}
+
+func TestGetPaymentTokenTTL(t *testing.T) {
+	calls := 0
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"symbol":"WETH","decimals":18,"eth_price":"1","usd_price":"3000"}`))
+	})
+
+	ctx := context.Background()
+	addr := chain.RequireAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL), WithCacheTTL(EndpointGetPaymentToken, time.Hour))
+	now := time.Now()
+	cli.(*client).paymentTokens.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		_, err := cli.GetPaymentToken(ctx, chain.Ethereum, addr)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(time.Hour)
+	_, err := cli.GetPaymentToken(ctx, chain.Ethereum, addr)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	disabled := NewClient(WithBaseURL(srv.URL, srv.URL), WithCacheTTL(EndpointGetPaymentToken, 0))
+	for i := 0; i < 2; i++ {
+		_, err = disabled.GetPaymentToken(ctx, chain.Ethereum, addr)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 4, calls)
+}
+
+func TestGetPaymentTokenCacheInChain(t *testing.T) {
+	calls := 0
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		_, _ = w.Write([]byte(`{"symbol":"WETH","decimals":18,"eth_price":"1","usd_price":"3000"}`))
+	})
+
+	ctx := context.Background()
+	addr := chain.RequireAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	shared := NewLRUCache(10)
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL),
+		WithCache(shared), WithCacheTTL(EndpointGetPaymentToken, time.Hour))
+
+	var meta ResponseMeta
+	_, err := cli.GetPaymentToken(ctx, chain.Ethereum, addr, UseResponseMeta(&meta))
+	require.NoError(t, err)
+	assert.Equal(t, 42, meta.RateLimit.Remaining)
+
+	// 命中缓存时同样填充 meta
+	meta = ResponseMeta{}
+	resp, err := cli.GetPaymentToken(ctx, chain.Ethereum, addr, UseResponseMeta(&meta))
+	require.NoError(t, err)
+	assert.Equal(t, "WETH", resp.Symbol)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusOK, meta.StatusCode)
+	assert.JSONEq(t, `{"symbol":"WETH","decimals":18,"eth_price":"1","usd_price":"3000"}`, string(meta.Body))
+
+	// 支付代币只存于进程内缓存
+	assert.Equal(t, 0, shared.Len())
+	assert.Equal(t, 1, cli.(*client).paymentTokens.Len())
+}
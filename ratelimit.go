@@ -0,0 +1,234 @@
+package openseaapi
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/xTransact/errx/v3"
+)
+
+// RateLimitScope is a budget of the rate limiter.
+type RateLimitScope int
+
+const (
+	// RateLimitScopeRead is the budget of the read endpoints, and of the other endpoints not posting orders
+	// such as RefreshNftMetadata.
+	RateLimitScopeRead RateLimitScope = iota
+	// RateLimitScopePost is the budget of the marketplace POST endpoints, e.g. CreateListing or FulfillOffer.
+	RateLimitScopePost
+)
+
+func (s RateLimitScope) String() string {
+	switch s {
+	case RateLimitScopeRead:
+		return "read"
+	case RateLimitScopePost:
+		return "post"
+	default:
+		return ""
+	}
+}
+
+// rateLimitScopeOf returns the budget a call is accounted on, chosen by its endpoint:
+// only the marketplace endpoints posting orders use the POST budget.
+func rateLimitScopeOf(call *Request) RateLimitScope {
+	method := call.HTTPRequest.Method
+	if method == http.MethodGet || method == http.MethodHead ||
+		EndpointGroupOf(call.Endpoint) != EndpointGroupMarketplace ||
+		// BuildOffer 只生成待签名的 offer，不提交订单
+		call.Endpoint == EndpointBuildOffer {
+		return RateLimitScopeRead
+	}
+	return RateLimitScopePost
+}
+
+// RateLimitState is a snapshot of a budget of the rate limiter.
+type RateLimitState struct {
+	Scope RateLimitScope
+	// Rate is the number of requests allowed per second, 0 means unlimited.
+	Rate float64
+	// Tokens is the number of requests which can be sent right now.
+	// It is negative when requests are already queued.
+	Tokens float64
+	// Waiting is the number of requests currently blocked by the limiter.
+	Waiting int
+	// PausedUntil is set when OpenSea asked to slow down, through Retry-After or rate limit headers.
+	PausedUntil time.Time
+}
+
+// RateLimiter is a token bucket limiter with separate budgets for read and POST endpoints.
+// A single instance can be shared by several clients using the same API key, see WithRateLimiter.
+type RateLimiter struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	buckets [2]*tokenBucket
+}
+
+type tokenBucket struct {
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	waiting     int
+	pausedUntil time.Time
+}
+
+// NewRateLimiter creates a limiter allowing readPerSecond read requests and postPerSecond marketplace POST requests,
+// see RateLimitScope.
+// A rate <= 0 leaves the budget unlimited, the burst of a budget is its rate rounded up.
+func NewRateLimiter(readPerSecond, postPerSecond float64) *RateLimiter {
+	l := &RateLimiter{now: time.Now}
+	now := l.now()
+	for i, rate := range []float64{readPerSecond, postPerSecond} {
+		burst := math.Max(1, math.Ceil(rate))
+		l.buckets[i] = &tokenBucket{
+			rate:   math.Max(rate, 0),
+			burst:  burst,
+			tokens: burst,
+			last:   now,
+		}
+	}
+	return l
+}
+
+func (l *RateLimiter) bucket(scope RateLimitScope) *tokenBucket {
+	if scope == RateLimitScopePost {
+		return l.buckets[RateLimitScopePost]
+	}
+	return l.buckets[RateLimitScopeRead]
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// Wait blocks until a request of the given scope may be sent, or ctx is done.
+// It fails fast when the wait would exceed the deadline of ctx.
+func (l *RateLimiter) Wait(ctx context.Context, scope RateLimitScope) error {
+	l.mu.Lock()
+	b := l.bucket(scope)
+	now := l.now()
+
+	var wait time.Duration
+	if b.pausedUntil.After(now) {
+		wait = b.pausedUntil.Sub(now)
+	}
+	if b.rate > 0 {
+		b.refill(now)
+		b.tokens--
+		if b.tokens < 0 {
+			wait = max(wait, time.Duration(-b.tokens/b.rate*float64(time.Second)))
+		}
+	}
+	if wait <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(now) < wait {
+		l.release(b)
+		l.mu.Unlock()
+		return errx.Wrapf(context.DeadlineExceeded, "rate limiter: %s wait of %s exceeds the context deadline", scope, wait)
+	}
+	b.waiting++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		b.waiting--
+		l.release(b)
+		l.mu.Unlock()
+		return errx.WithStack(ctx.Err())
+	case <-timer.C:
+		l.mu.Lock()
+		b.waiting--
+		l.mu.Unlock()
+		return nil
+	}
+}
+
+// release gives back the token reserved by an abandoned Wait.
+func (l *RateLimiter) release(b *tokenBucket) {
+	if b.rate > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+1)
+	}
+}
+
+// State returns a snapshot of the budget of the given scope.
+func (l *RateLimiter) State(scope RateLimitScope) RateLimitState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(scope)
+	if b.rate > 0 {
+		b.refill(l.now())
+	}
+
+	return RateLimitState{
+		Scope:       scope,
+		Rate:        b.rate,
+		Tokens:      b.tokens,
+		Waiting:     b.waiting,
+		PausedUntil: b.pausedUntil,
+	}
+}
+
+// Pause blocks every request of the scope until the given time.
+func (l *RateLimiter) Pause(scope RateLimitScope, until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b := l.bucket(scope); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+// Observe adapts the limiter to a response of OpenSea:
+// a Retry-After header, or an exhausted X-RateLimit-Remaining with its X-RateLimit-Reset,
+// pauses the scope until the server accepts requests again.
+func (l *RateLimiter) Observe(scope RateLimitScope, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	now := l.now()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if wait, ok := parseRetryAfter(resp); ok {
+			l.Pause(scope, now.Add(wait))
+			return
+		}
+	}
+
+	remaining, err := strconv.ParseFloat(resp.Header.Get("X-RateLimit-Remaining"), 64)
+	if err != nil || remaining > 0 {
+		return
+	}
+	if until, ok := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), now); ok {
+		l.Pause(scope, until)
+	}
+}
+
+// parseRateLimitReset parses X-RateLimit-Reset, which is either a delay in seconds or a unix timestamp.
+func parseRateLimitReset(v string, now time.Time) (time.Time, bool) {
+	reset, err := strconv.ParseFloat(v, 64)
+	if err != nil || reset < 0 {
+		return time.Time{}, false
+	}
+
+	// 大于 10 亿视为 unix 时间戳
+	if reset > 1e9 {
+		return time.Unix(0, int64(reset*float64(time.Second))), true
+	}
+	return now.Add(time.Duration(reset * float64(time.Second))), true
+}
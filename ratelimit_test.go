@@ -0,0 +1,112 @@
+package openseaapi
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterSeparatesScopes(t *testing.T) {
+	l := NewRateLimiter(1, 0)
+	ctx := context.Background()
+
+	require.NoError(t, l.Wait(ctx, RateLimitScopeRead))
+
+	// the read budget is exhausted and the next token is a second away
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, l.Wait(short, RateLimitScopeRead), context.DeadlineExceeded)
+
+	// the post budget is unlimited
+	for i := 0; i < 10; i++ {
+		require.NoError(t, l.Wait(ctx, RateLimitScopePost))
+	}
+
+	state := l.State(RateLimitScopeRead)
+	assert.Equal(t, 1.0, state.Rate)
+	assert.Zero(t, state.Waiting)
+	assert.Less(t, state.Tokens, 1.0)
+}
+
+func TestRateLimiterReportsWaiting(t *testing.T) {
+	l := NewRateLimiter(20, 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, l.Wait(ctx, RateLimitScopeRead))
+	}
+
+	done := make(chan error)
+	go func() { done <- l.Wait(ctx, RateLimitScopeRead) }()
+
+	require.Eventually(t, func() bool {
+		return l.State(RateLimitScopeRead).Waiting == 1
+	}, time.Second, time.Millisecond)
+	require.NoError(t, <-done)
+	assert.Zero(t, l.State(RateLimitScopeRead).Waiting)
+}
+
+func TestRateLimiterObservesHeaders(t *testing.T) {
+	l := NewRateLimiter(0, 0)
+
+	l.Observe(RateLimitScopeRead, &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"X-Ratelimit-Remaining": {"0"},
+			"X-Ratelimit-Reset":     {"2"},
+		},
+	})
+	assert.WithinDuration(t, time.Now().Add(2*time.Second), l.State(RateLimitScopeRead).PausedUntil, 100*time.Millisecond)
+	assert.True(t, l.State(RateLimitScopePost).PausedUntil.IsZero())
+
+	l.Observe(RateLimitScopePost, &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {"5"}},
+	})
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), l.State(RateLimitScopePost).PausedUntil, 100*time.Millisecond)
+}
+
+func TestWithRateLimiterShared(t *testing.T) {
+	var hits atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	limiter := NewRateLimiter(1, 1)
+	a := NewClient(WithBaseURL(srv.URL, ""), WithRateLimiter(limiter))
+	b := NewClient(WithBaseURL(srv.URL, ""), WithRateLimiter(limiter))
+
+	_, err := a.GetCollection(context.Background(), "azuki")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = b.GetCollection(ctx, "azuki")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualValues(t, 1, hits.Load())
+}
+
+func TestRateLimitScopeOf(t *testing.T) {
+	for endpoint, scope := range map[string]RateLimitScope{
+		EndpointCreateListing:      RateLimitScopePost,
+		EndpointFulfillOffer:       RateLimitScopePost,
+		EndpointCancelOrder:        RateLimitScopePost,
+		EndpointBuildOffer:         RateLimitScopeRead,
+		EndpointRefreshNftMetadata: RateLimitScopeRead,
+	} {
+		req, err := http.NewRequest(http.MethodPost, "https://api.opensea.io", nil)
+		require.NoError(t, err)
+		assert.Equal(t, scope, rateLimitScopeOf(&Request{Endpoint: endpoint, HTTPRequest: req}), endpoint)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.opensea.io", nil)
+	require.NoError(t, err)
+	assert.Equal(t, RateLimitScopeRead, rateLimitScopeOf(&Request{Endpoint: EndpointGetListings, HTTPRequest: req}))
+}
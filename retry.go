@@ -0,0 +1,249 @@
+package openseaapi
+
+import (
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/xTransact/errx/v3"
+)
+
+// RetryPolicy decides whether a failed attempt should be retried and how long to wait before the next one.
+type RetryPolicy interface {
+	// Backoff is called after every attempt with the response or error it produced.
+	// attempt starts at 1. It returns retry = false when the request must not be retried.
+	Backoff(attempt int, resp *http.Response, err error) (wait time.Duration, retry bool)
+}
+
+const (
+	defaultRetryMaxAttempts = 5
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 30 * time.Second
+	defaultRetryJitter      = 0.2
+)
+
+// BackoffRetryPolicy retries throttled, failed and timed out requests with exponential backoff and jitter.
+// A Retry-After header sent by the server takes precedence over the computed backoff.
+type BackoffRetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one. Default: 5
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry, it doubles on every following retry. Default: 500ms
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between two attempts, including the one given by Retry-After. Default: 30s
+	MaxDelay time.Duration
+	// Jitter is the fraction (between 0 and 1) of the wait which is randomized. 0 disables jitter.
+	Jitter float64
+	// Retryable overrides the default classification of retryable responses and errors.
+	Retryable func(resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured:
+// up to 5 attempts on 429, 5xx, timeouts and connection resets, starting at 500ms with 20% jitter.
+func DefaultRetryPolicy() *BackoffRetryPolicy {
+	return &BackoffRetryPolicy{
+		MaxAttempts: defaultRetryMaxAttempts,
+		BaseDelay:   defaultRetryBaseDelay,
+		MaxDelay:    defaultRetryMaxDelay,
+		Jitter:      defaultRetryJitter,
+	}
+}
+
+func (p *BackoffRetryPolicy) Backoff(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultRetryMaxAttempts
+	}
+	if attempt >= maxAttempts {
+		return 0, false
+	}
+
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = shouldRetry
+	}
+	if !retryable(resp, err) {
+		return 0, false
+	}
+
+	baseDelay := p.BaseDelay
+	if baseDelay <= 0 {
+		baseDelay = defaultRetryBaseDelay
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+
+	if wait, ok := parseRetryAfter(resp); ok {
+		return min(wait, maxDelay), true
+	}
+
+	wait := time.Duration(float64(baseDelay) * math.Pow(2, float64(attempt-1)))
+	if wait <= 0 || wait > maxDelay {
+		wait = maxDelay
+	}
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		// 在 [wait*(1-jitter), wait] 区间内随机
+		wait -= time.Duration(rand.Float64() * jitter * float64(wait))
+	}
+
+	return wait, true
+}
+
+type noRetryPolicy struct{}
+
+func (noRetryPolicy) Backoff(int, *http.Response, error) (time.Duration, bool) {
+	return 0, false
+}
+
+// NoRetryPolicy returns a policy which never retries.
+func NoRetryPolicy() RetryPolicy {
+	return noRetryPolicy{}
+}
+
+// shouldRetry 判断是否需要进行重试
+// 针对 429 StatusTooManyRequests、5xx、超时以及连接被重置进行重试
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return isRetryableTransportError(err)
+	}
+
+	return resp != nil && isRetryableStatus(resp.StatusCode)
+}
+
+// parseRetryAfter parses the Retry-After header, which is either delay-seconds or an HTTP-date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+
+	return 0, false
+}
+
+// rewindRequest prepares a request to be sent again, rebuilding its body through GetBody.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		return nil, errx.New("request body can not be replayed: GetBody is nil")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, errx.Wrap(err, "rebuild request body")
+	}
+
+	r := req.Clone(req.Context())
+	r.Body = body
+	return r, nil
+}
+
+// doWithRetry sends the request, retrying it according to the policy.
+// Every attempt waits for the rate limiter, if any, and picks its API key from the key pool, if any.
+// It returns the number of attempts made, including the first one.
+func (c *client) doWithRetry(call *Request) (resp *http.Response, attempts int, err error) {
+	req := call.HTTPRequest
+	ctx := req.Context()
+	policy := c.retryPolicy(call.options)
+	limiter := c.config.rateLimiter
+	scope := rateLimitScopeOf(call)
+	pool := c.config.keyPool
+	if call.Testnets || call.options.apiKey != "" {
+		// 测试网不需要 API Key，单次请求指定的 key 优先于 key 池
+		pool = nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			if req, err = rewindRequest(req); err != nil {
+				return nil, attempts, err
+			}
+		}
+
+		if limiter != nil {
+			if err = limiter.Wait(ctx, scope); err != nil {
+				return nil, attempts, err
+			}
+		}
+
+		attempts = attempt
+
+		// 每次尝试都重新选 key，被限流的 key 重试时会换成其他 key
+		var key string
+		if pool != nil {
+			var ok bool
+			if key, ok = pool.pick(); ok {
+				req.Header.Set("x-api-key", key)
+			}
+		}
+
+		resp, err = c.httpClient.Do(req)
+		err = errx.WithStack(err)
+		if limiter != nil {
+			limiter.Observe(scope, resp)
+		}
+		if key != "" {
+			pool.observe(key, resp)
+		}
+		if c.config.metrics != nil {
+			c.config.metrics.observeAttempt(call, attempt, resp)
+		}
+
+		wait, retry := policy.Backoff(attempt, resp, err)
+		if !retry || ctx.Err() != nil {
+			return
+		}
+		// 其他调用已经触发熔断，不再重试
+		if c.config.breaker != nil && c.config.breaker.peek(EndpointGroupOf(call.Endpoint)) == BreakerOpen {
+			return
+		}
+		// 等待时间超过了 ctx 的截止时间，直接返回本次的结果
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return
+		}
+
+		status := ""
+		if resp != nil {
+			status = resp.Status
+		}
+		c.logger().WarnContext(ctx, "[OpenSea API] Failed to do http request, attempting retry...",
+			"attempts", attempt,
+			"err", err,
+			"status", status,
+			"wait", wait)
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempts, errx.WithStack(ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
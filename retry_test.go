@@ -0,0 +1,97 @@
+package openseaapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+func fastRetryPolicy() *BackoffRetryPolicy {
+	return &BackoffRetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    10 * time.Millisecond,
+	}
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	var attempts atomic.Int32
+	var bodies []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"order_hash":"0x01"}`))
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithRetryPolicy(fastRetryPolicy()))
+	resp, err := cli.CreateCriteriaOffer(context.Background(), &openseamodels.CreateCriteriaOfferPayload{
+		ProtocolData:    &openseamodels.ProtocolData{Signature: "0xsig"},
+		Criteria:        &openseamodels.Criteria{},
+		ProtocolAddress: "0x0000000000000068f116a894984e2db1123eb395",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0x01", resp.OrderHash)
+	require.Len(t, bodies, 3)
+	assert.NotEmpty(t, bodies[0])
+	assert.Equal(t, bodies[0], bodies[1])
+	assert.Equal(t, bodies[0], bodies[2])
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	policy := &BackoffRetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Minute}
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"7"}}}
+
+	wait, retry := policy.Backoff(1, resp, nil)
+	assert.True(t, retry)
+	assert.Equal(t, 7*time.Second, wait)
+
+	_, retry = policy.Backoff(5, resp, nil)
+	assert.False(t, retry)
+
+	_, retry = policy.Backoff(1, &http.Response{StatusCode: http.StatusBadRequest}, nil)
+	assert.False(t, retry)
+}
+
+func TestRetryStopsOnContext(t *testing.T) {
+	var attempts atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := cli.GetCollection(ctx, "azuki")
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.EqualValues(t, 1, attempts.Load())
+}
+
+func TestNoRetryPolicy(t *testing.T) {
+	var attempts atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithRetryPolicy(fastRetryPolicy()))
+	_, err := cli.GetCollection(context.Background(), "azuki", UseRetryPolicy(NoRetryPolicy()))
+	require.Error(t, err)
+	assert.EqualValues(t, 1, attempts.Load())
+}
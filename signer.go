@@ -0,0 +1,208 @@
+package openseaapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/xTransact/errx/v3"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+// Signer signs the orders created through CreateListing and CreateIndividualOffer, see WithSigner.
+type Signer interface {
+	// Address returns the address of the key, which must be the offerer of the signed orders.
+	Address() common.Address
+	// SignTypedData signs the EIP-712 typed data, returning a 65 bytes [R || S || V] signature.
+	SignTypedData(ctx context.Context, typedData *apitypes.TypedData) ([]byte, error)
+}
+
+type ecdsaSigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewECDSASigner signs with a private key held in memory.
+func NewECDSASigner(key *ecdsa.PrivateKey) Signer {
+	return &ecdsaSigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// NewPrivateKeySigner signs with a hex encoded private key, with or without the 0x prefix.
+func NewPrivateKeySigner(hexKey string) (Signer, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return nil, errx.Wrap(err, "invalid private key")
+	}
+	return NewECDSASigner(key), nil
+}
+
+// NewKeystoreSigner signs with the key of a go-ethereum encrypted keystore file, decrypted with the passphrase.
+func NewKeystoreSigner(keyJSON []byte, passphrase string) (Signer, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, errx.Wrap(err, "decrypt keystore")
+	}
+	return NewECDSASigner(key.PrivateKey), nil
+}
+
+func (s *ecdsaSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *ecdsaSigner) SignTypedData(_ context.Context, typedData *apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(*typedData)
+	if err != nil {
+		return nil, errx.Wrap(err, "hash typed data")
+	}
+	sig, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+type remoteSigner struct {
+	url        string
+	address    common.Address
+	httpClient *http.Client
+	id         atomic.Int64
+}
+
+// NewRemoteSigner signs through the eth_signTypedData_v4 JSON-RPC method of a remote signer,
+// e.g. Clef, Web3Signer or a custody service, holding the key of the address.
+// The requests are sent by httpClient, whose transport may authenticate them. Default: a client with a 30s timeout
+func NewRemoteSigner(url string, address common.Address, httpClient *http.Client) Signer {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &remoteSigner{url: url, address: address, httpClient: httpClient}
+}
+
+func (s *remoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *remoteSigner) SignTypedData(ctx context.Context, typedData *apitypes.TypedData) ([]byte, error) {
+	reqBody, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      s.id.Add(1),
+		"method":  "eth_signTypedData_v4",
+		"params":  []any{s.address.Hex(), typedData},
+	})
+	if err != nil {
+		return nil, errx.Wrap(err, "marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+	req.Header.Set("content-type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errx.WithStack(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errx.Errorf("remote signer: %s: %s", resp.Status, body)
+	}
+
+	var rpcResp struct {
+		Result hexutil.Bytes `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err = json.Unmarshal(body, &rpcResp); err != nil {
+		return nil, errx.Wrap(err, "unmarshal response body")
+	}
+	if rpcResp.Error != nil {
+		return nil, errx.Errorf("remote signer: %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+
+	return rpcResp.Result, nil
+}
+
+// SignOrder signs the parameters of the order with the signer and fills payload.Signature.
+// The typed data is the Seaport EIP-712 order of the payload's protocol_address on the chain,
+// see openseamodels.Parameters.TypedData. An empty offerer is filled with the address of the signer.
+func SignOrder(ctx context.Context, signer Signer, ch chain.Chain, payload *openseamodels.CreateOrderPayload) error {
+	if payload.Parameters == nil {
+		return errx.New("invalid parameters")
+	}
+
+	address := signer.Address()
+	if payload.Parameters.Offerer == "" {
+		payload.Parameters.Offerer = address.Hex()
+	}
+	if !strings.EqualFold(payload.Parameters.Offerer, address.Hex()) {
+		return errx.Errorf("offerer %s is not the signer %s", payload.Parameters.Offerer, address.Hex())
+	}
+
+	typedData, err := payload.Parameters.TypedData(ch, payload.ProtocolAddress)
+	if err != nil {
+		return err
+	}
+	hash, _, err := apitypes.TypedDataAndHash(*typedData)
+	if err != nil {
+		return errx.Wrap(err, "hash typed data")
+	}
+
+	sig, err := signer.SignTypedData(ctx, typedData)
+	if err != nil {
+		return errx.Wrap(err, "sign typed data")
+	}
+	if len(sig) != crypto.SignatureLength {
+		return errx.Errorf("invalid signature length: %d", len(sig))
+	}
+
+	// 签名的 V 可能为 0/1 或 27/28，统一为 27/28 并确认签名者
+	sig = bytes.Clone(sig)
+	if sig[crypto.RecoveryIDOffset] < 27 {
+		sig[crypto.RecoveryIDOffset] += 27
+	}
+	recoverable := bytes.Clone(sig)
+	recoverable[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(hash, recoverable)
+	if err != nil {
+		return errx.Wrap(err, "invalid signature")
+	}
+	if signed := crypto.PubkeyToAddress(*pub); signed != address {
+		return errx.Errorf("order signed by %s instead of %s", signed.Hex(), address.Hex())
+	}
+
+	payload.Signature = hexutil.Encode(sig)
+	return nil
+}
+
+// signOrder signs the order with the signer of the client, unless it is already signed.
+func (c *client) signOrder(ctx context.Context, ch chain.Chain, payload *openseamodels.CreateOrderPayload) error {
+	if payload.Signature != "" || (c.signer == nil && c.signerErr == nil) {
+		return nil
+	}
+	if c.signerErr != nil {
+		return c.signerErr
+	}
+	return SignOrder(ctx, c.signer, ch, payload)
+}
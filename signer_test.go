@@ -0,0 +1,268 @@
+package openseaapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseaconsts"
+	"github.com/xTransact/openseaapi/openseaenums"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+// testPrivateKey is the first account of the hardhat and anvil test mnemonic.
+const (
+	testPrivateKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	testSigner     = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+)
+
+func testOrderPayload() *openseamodels.CreateOrderPayload {
+	return &openseamodels.CreateOrderPayload{
+		Parameters: &openseamodels.Parameters{
+			Offer: []*openseamodels.Offer{{BaseOfferAndConsideration: &openseamodels.BaseOfferAndConsideration{
+				ItemType:             openseaenums.ItemTypeERC721,
+				Token:                common.HexToAddress("0xED5AF388653567Af2F388E6224dC7C4b3241C544"),
+				IdentifierOrCriteria: "7",
+				StartAmount:          "1",
+				EndAmount:            "1",
+			}}},
+			Consideration: []*openseamodels.Consideration{{
+				BaseOfferAndConsideration: &openseamodels.BaseOfferAndConsideration{
+					ItemType:             openseaenums.ItemTypeNative,
+					IdentifierOrCriteria: "0",
+					StartAmount:          "1000000000000000000",
+					EndAmount:            "1000000000000000000",
+				},
+				Recipient: common.HexToAddress(testSigner),
+			}},
+			StartTime:                       "1700641471",
+			EndTime:                         "1700727871",
+			Zone:                            "0x0000000000000000000000000000000000000000",
+			ZoneHash:                        "0x0000000000000000000000000000000000000000000000000000000000000000",
+			Salt:                            "12345",
+			ConduitKey:                      "0x0000007b02230091a7ed01230072f7006a004d60a8d4e71d599b8104250f0000",
+			TotalOriginalConsiderationItems: "1",
+			Counter:                         "0",
+		},
+		ProtocolAddress: openseaconsts.SeaportV16Address.Hex(),
+	}
+}
+
+// requireSignedBy checks the signature of the payload against the Seaport order hash.
+func requireSignedBy(t *testing.T, ch chain.Chain, payload *openseamodels.CreateOrderPayload, signer string) {
+	hash, err := payload.Parameters.Hash(ch, payload.ProtocolAddress)
+	require.NoError(t, err)
+	sig, err := hexutil.Decode(payload.Signature)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+	require.Contains(t, []byte{27, 28}, sig[64])
+	sig[64] -= 27
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	require.NoError(t, err)
+	assert.Equal(t, signer, crypto.PubkeyToAddress(*pub).Hex())
+}
+
+func TestECDSASigner(t *testing.T) {
+	// EIP-712 规范中的示例
+	typedData := &apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Person": {
+				{Name: "name", Type: "string"},
+				{Name: "wallet", Type: "address"},
+			},
+			"Mail": {
+				{Name: "from", Type: "Person"},
+				{Name: "to", Type: "Person"},
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Ether Mail",
+			Version:           "1",
+			ChainId:           math.NewHexOrDecimal256(1),
+			VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":     map[string]any{"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+			"to":       map[string]any{"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+			"contents": "Hello, Bob!",
+		},
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(*typedData)
+	require.NoError(t, err)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hexutil.Encode(hash))
+
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	require.NoError(t, err)
+	signer := NewECDSASigner(key)
+	assert.Equal(t, "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", signer.Address().Hex())
+
+	sig, err := signer.SignTypedData(context.Background(), typedData)
+	require.NoError(t, err)
+	assert.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+"1c", hexutil.Encode(sig))
+
+	_, err = NewPrivateKeySigner("0x1234")
+	assert.Error(t, err)
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKey[2:])
+	require.NoError(t, err)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey: key,
+	}, "secret", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	_, err = NewKeystoreSigner(keyJSON, "wrong")
+	assert.Error(t, err)
+
+	signer, err := NewKeystoreSigner(keyJSON, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, testSigner, signer.Address().Hex())
+
+	payload := testOrderPayload()
+	require.NoError(t, SignOrder(context.Background(), signer, chain.Ethereum, payload))
+	assert.Equal(t, testSigner, payload.Parameters.Offerer)
+	requireSignedBy(t, chain.Ethereum, payload, testSigner)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKey[2:])
+	require.NoError(t, err)
+
+	var (
+		rejected  bool
+		addresses []string
+	)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		var req struct {
+			ID     int64             `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "eth_signTypedData_v4", req.Method)
+		require.Len(t, req.Params, 2)
+		var address string
+		require.NoError(t, json.Unmarshal(req.Params[0], &address))
+		addresses = append(addresses, address)
+
+		if rejected {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"request rejected"}}`))
+			return
+		}
+
+		var typedData apitypes.TypedData
+		require.NoError(t, json.Unmarshal(req.Params[1], &typedData))
+		assert.Equal(t, "Seaport", typedData.Domain.Name)
+		hash, _, err := apitypes.TypedDataAndHash(typedData)
+		require.NoError(t, err)
+		// V 为 0/1，由 SignOrder 转换
+		sig, err := crypto.Sign(hash, key)
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": hexutil.Encode(sig)})
+	})
+
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		r.Header.Set("Authorization", "Bearer token")
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	signer := NewRemoteSigner(srv.URL, common.HexToAddress(testSigner), httpClient)
+
+	payload := testOrderPayload()
+	require.NoError(t, SignOrder(context.Background(), signer, chain.Matic, payload))
+	requireSignedBy(t, chain.Matic, payload, testSigner)
+
+	rejected = true
+	payload = testOrderPayload()
+	err = SignOrder(context.Background(), signer, chain.Matic, payload)
+	assert.ErrorContains(t, err, "request rejected")
+	assert.Empty(t, payload.Signature)
+
+	// 远端签名者持有的不是该地址的私钥
+	rejected = false
+	payload = testOrderPayload()
+	other := NewRemoteSigner(srv.URL, common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), httpClient)
+	payload.Parameters.Offerer = other.Address().Hex()
+	assert.Error(t, SignOrder(context.Background(), other, chain.Matic, payload))
+	assert.Empty(t, payload.Signature)
+
+	assert.Equal(t, []string{testSigner, testSigner, other.Address().Hex()}, addresses)
+}
+
+func TestSignOrderOffererMismatch(t *testing.T) {
+	signer, err := NewPrivateKeySigner(testPrivateKey)
+	require.NoError(t, err)
+
+	payload := testOrderPayload()
+	payload.Parameters.Offerer = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+	assert.Error(t, SignOrder(context.Background(), signer, chain.Ethereum, payload))
+
+	payload = testOrderPayload()
+	payload.ProtocolAddress = "0x00000000006c3852cbEf3e08E8dF289169EdE581"
+	assert.Error(t, SignOrder(context.Background(), signer, chain.Ethereum, payload))
+	assert.Empty(t, payload.Signature)
+}
+
+func TestCreateListingSigned(t *testing.T) {
+	var bodies []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		_, _ = w.Write([]byte(`{"order":{"order_hash":"0xabc"}}`))
+	})
+
+	ctx := context.Background()
+	cli := NewClient(WithBaseURL(srv.URL, srv.URL), WithPrivateKey(testPrivateKey))
+
+	_, err := cli.CreateListing(ctx, chain.Ethereum, testOrderPayload())
+	require.NoError(t, err)
+	_, err = cli.CreateIndividualOffer(ctx, chain.Sepolia, testOrderPayload())
+	require.NoError(t, err)
+	require.Len(t, bodies, 2)
+
+	for i, ch := range []chain.Chain{chain.Ethereum, chain.Sepolia} {
+		var sent openseamodels.CreateOrderPayload
+		require.NoError(t, json.Unmarshal([]byte(bodies[i]), &sent))
+		assert.Equal(t, testSigner, sent.Parameters.Offerer)
+		requireSignedBy(t, ch, &sent, testSigner)
+	}
+
+	// 已签名的订单保持不变
+	payload := testOrderPayload()
+	payload.Parameters.Offerer = testSigner
+	payload.Signature = "0x1234"
+	_, err = cli.CreateListing(ctx, chain.Ethereum, payload)
+	require.NoError(t, err)
+	assert.Equal(t, "0x1234", payload.Signature)
+
+	// 无效的私钥不发出请求
+	cli = NewClient(WithBaseURL(srv.URL, srv.URL), WithPrivateKey("0x1234"))
+	_, err = cli.CreateListing(ctx, chain.Ethereum, testOrderPayload())
+	assert.Error(t, err)
+	assert.Len(t, bodies, 3)
+}
@@ -0,0 +1,157 @@
+package openseaapi
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+const instrumentationName = "github.com/xTransact/openseaapi"
+
+// Span attributes set on every call traced through WithTracerProvider.
+const (
+	AttributeEndpoint        = attribute.Key("opensea.endpoint")
+	AttributeChain           = attribute.Key("opensea.chain")
+	AttributeTestnets        = attribute.Key("opensea.testnets")
+	AttributeCollectionSlug  = attribute.Key("opensea.collection_slug")
+	AttributeContractAddress = attribute.Key("opensea.contract_address")
+	AttributeOrderHash       = attribute.Key("opensea.order_hash")
+	AttributeRetryCount      = attribute.Key("opensea.retry_count")
+	AttributeHTTPStatusCode  = attribute.Key("http.response.status_code")
+)
+
+// callSubject is what a call is about, extracted from its payload.
+type callSubject struct {
+	collectionSlug  string
+	contractAddress string
+	orderHash       string
+}
+
+// subjectOf extracts the collection slug, contract address and order hash of a call from its payload.
+func subjectOf(req *Request) (s callSubject) {
+	switch p := req.Payload.(type) {
+	case string:
+		// GetCollection, GetCollectionStats, GetTraits and GetCollectionOffers take a collection slug
+		s.collectionSlug = p
+	case chain.Address:
+		if req.Endpoint == EndpointGetContract {
+			s.contractAddress = p.String()
+		}
+	case *openseamodels.CollectionPayload:
+		s.collectionSlug = p.CollectionSlug
+	case *openseamodels.GetAllListingsByCollectionPayload:
+		s.collectionSlug = p.CollectionSlug
+	case *openseamodels.GetTraitOffersPayload:
+		s.collectionSlug = p.CollectionSlug
+	case *openseamodels.GetBestListingPayload:
+		s.collectionSlug = p.CollectionSlug
+	case *openseamodels.GetBestListingsPayload:
+		s.collectionSlug = p.CollectionSlug
+	case *openseamodels.GetBestOfferPayload:
+		s.collectionSlug = p.CollectionSlug
+	case *openseamodels.GetEventsByCollectionPayload:
+		s.collectionSlug = p.CollectionSlug
+	case *openseamodels.GetNftsByAccountPayload:
+		s.collectionSlug = p.Collection
+	case *openseamodels.GetNftsByContractPayload:
+		if p.GetNftsBasePayload != nil {
+			s.contractAddress = p.Address.String()
+		}
+	case *openseamodels.GetNftPayload:
+		s.contractAddress = p.Address.String()
+	case *openseamodels.ValidateNftMetadataPayload:
+		s.contractAddress = p.Address.String()
+	case *openseamodels.GetEventsByNftPayload:
+		s.contractAddress = p.Address
+	case *openseamodels.OrderPayload:
+		if p.AssetContractAddress != nil {
+			s.contractAddress = *p.AssetContractAddress
+		}
+	case *openseamodels.GetOrderPayload:
+		s.orderHash = p.OrderHash
+	case *openseamodels.CancelOrderPayload:
+		s.orderHash = p.OrderHash
+	case *openseamodels.FulfillListingPayload:
+		if p.Listing != nil {
+			s.orderHash = p.Listing.Hash
+		}
+	case *openseamodels.FulfillOfferPayload:
+		if p.Offer != nil {
+			s.orderHash = p.Offer.Hash
+		}
+		if p.Consideration != nil {
+			s.contractAddress = p.Consideration.AssetContractAddress.String()
+		}
+	case *openseamodels.BuildOfferPayload:
+		if p.Criteria != nil {
+			s.collectionSlug, s.contractAddress = criteriaSubject(p.Criteria)
+		}
+	case *openseamodels.CreateCriteriaOfferPayload:
+		if p.Criteria != nil {
+			s.collectionSlug, s.contractAddress = criteriaSubject(p.Criteria)
+		}
+	}
+	return s
+}
+
+func criteriaSubject(c *openseamodels.Criteria) (collectionSlug, contractAddress string) {
+	if c.Collection != nil {
+		collectionSlug = c.Collection.Slug
+	}
+	if c.Contract != nil {
+		contractAddress = c.Contract.Address
+	}
+	return
+}
+
+// tracing starts a span for every call going through the doer, see WithTracerProvider.
+// The span is a child of the span found in the context of the call.
+func (c *client) tracing(next Doer) Doer {
+	tracer := c.config.tracerProvider.Tracer(instrumentationName)
+
+	return DoerFunc(func(req *Request) (*Response, error) {
+		attrs := []attribute.KeyValue{
+			AttributeEndpoint.String(req.Endpoint),
+			AttributeTestnets.Bool(req.Testnets),
+		}
+		if req.Chain != 0 {
+			attrs = append(attrs, AttributeChain.String(req.Chain.Value()))
+		}
+		subject := subjectOf(req)
+		if subject.collectionSlug != "" {
+			attrs = append(attrs, AttributeCollectionSlug.String(subject.collectionSlug))
+		}
+		if subject.contractAddress != "" {
+			attrs = append(attrs, AttributeContractAddress.String(subject.contractAddress))
+		}
+		if subject.orderHash != "" {
+			attrs = append(attrs, AttributeOrderHash.String(subject.orderHash))
+		}
+
+		ctx, span := tracer.Start(req.Context(), "opensea."+req.Endpoint,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...))
+		defer span.End()
+
+		req.HTTPRequest = req.HTTPRequest.WithContext(ctx)
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.HTTPRequest.Header))
+
+		resp, err := next.Do(req)
+
+		span.SetAttributes(AttributeRetryCount.Int(max(req.attempts-1, 0)))
+		if resp != nil {
+			span.SetAttributes(AttributeHTTPStatusCode.Int(resp.StatusCode))
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return resp, err
+	})
+}
@@ -0,0 +1,91 @@
+package openseaapi
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/xTransact/openseaapi/chain"
+	"github.com/xTransact/openseaapi/openseamodels"
+)
+
+func TestWithTracerProvider(t *testing.T) {
+	var attempts atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"order_hash":"0xabc"}`))
+	})
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	cli := NewClient(
+		WithBaseURL(srv.URL, ""),
+		WithTracerProvider(provider),
+		WithRetryPolicy(&BackoffRetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+	)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, err := cli.GetOrder(ctx, &openseamodels.GetOrderPayload{
+		Chain:           chain.Ethereum.Value(),
+		OrderHash:       "0xabc",
+		ProtocolAddress: "0x0000000000000068f116a894984e2db1123eb395",
+	})
+	parent.End()
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "opensea.GetOrder", span.Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+
+	attrs := attribute.NewSet(span.Attributes...)
+	value, _ := attrs.Value(AttributeEndpoint)
+	assert.Equal(t, EndpointGetOrder, value.AsString())
+	value, _ = attrs.Value(AttributeChain)
+	assert.Equal(t, "ethereum", value.AsString())
+	value, _ = attrs.Value(AttributeOrderHash)
+	assert.Equal(t, "0xabc", value.AsString())
+	value, _ = attrs.Value(AttributeRetryCount)
+	assert.EqualValues(t, 1, value.AsInt64())
+	value, _ = attrs.Value(AttributeHTTPStatusCode)
+	assert.EqualValues(t, http.StatusOK, value.AsInt64())
+}
+
+func TestTracingRecordsErrors(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	cli := NewClient(WithBaseURL(srv.URL, ""), WithTracerProvider(provider))
+	_, err := cli.GetCollection(context.Background(), "azuki")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+
+	attrs := attribute.NewSet(spans[0].Attributes...)
+	value, _ := attrs.Value(AttributeCollectionSlug)
+	assert.Equal(t, "azuki", value.AsString())
+	value, _ = attrs.Value(AttributeHTTPStatusCode)
+	assert.EqualValues(t, http.StatusNotFound, value.AsInt64())
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/xTransact/errx/v3"
 
-	"github.com/xTransact/openseaapi/openseaapiutils"
 	"github.com/xTransact/openseaapi/openseamodels"
 )
 
@@ -23,9 +22,10 @@ func (c *client) GetTraits(ctx context.Context, collectionSlug string, opts ...R
 	for _, apply := range opts {
 		apply(o)
 	}
+	o.endpoint, o.payload = EndpointGetTraits, collectionSlug
 
 	// GET /api/v2/traits/{collection_slug}
-	url := fmt.Sprintf("%s/api/v2/traits/%s", openseaapiutils.GetBaseURL(o.testnets), collectionSlug)
+	url := fmt.Sprintf("%s/api/v2/traits/%s", c.baseURL(o), collectionSlug)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -33,7 +33,7 @@ func (c *client) GetTraits(ctx context.Context, collectionSlug string, opts ...R
 	}
 
 	c.acceptJson(req)
-	body, err := c.doRequest(req, o.testnets)
+	body, err := c.doRequest(req, o)
 	if err != nil {
 		return nil, errx.WithStack(err)
 	}